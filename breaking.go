@@ -0,0 +1,150 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"fmt"
+	"sort"
+)
+
+// BreakingChanges returns the changes from old to new which are incompatible with existing consumers, each with a Reason.
+// These are removed paths, operations, and response codes, parameters which become required,
+// enumerated values which are removed, or added where any value was allowed, and type changes of parameters and of properties of component schemas.
+// Changes to component schemas have the reference to the schema as their Path, such as "#/components/schemas/Pet".
+func BreakingChanges(old, new API) []Change {
+	var breaking []Change
+	d := Diff(old, new)
+
+	reasons := []struct {
+		changes []Change
+		reason  string
+	}{
+		{d.Paths, "clients calling the path will fail"},
+		{d.Operations, "clients calling the operation will fail"},
+		{d.Responses, "clients may rely on the response"},
+	}
+	for _, r := range reasons {
+		for _, c := range r.changes {
+			if c.Kind == "removed" {
+				c.Reason = r.reason
+				breaking = append(breaking, c)
+			}
+		}
+	}
+
+	old.ForEachOperation(func(path, verb string, m Method) {
+		if _, ok := new.Paths[path].Methods[verb]; ok {
+			breaking = append(breaking, breakingParameters(path, verb, old.EffectiveParameters(path, verb), new.EffectiveParameters(path, verb))...)
+		}
+	})
+
+	sort.SliceStable(breaking, func(i, j int) bool {
+		if breaking[i].Path != breaking[j].Path {
+			return breaking[i].Path < breaking[j].Path
+		}
+		return verbLess(breaking[i].Verb, breaking[j].Verb)
+	})
+
+	oldSchemas, newSchemas := old.Components["schemas"], new.Components["schemas"]
+	for _, name := range sortedKeys(oldSchemas) {
+		n, ok := newSchemas[name]
+		if !ok {
+			continue
+		}
+		ref := componentRef("schemas", name)
+		o := oldSchemas[name]
+
+		if detail := enumChange(o.Enums, n.Enums); detail != "" {
+			breaking = append(breaking, Change{Kind: "changed", Path: ref, Detail: detail, Reason: "consumers may use a value no longer allowed"})
+		}
+
+		for _, prop := range sortedKeys(o.Properties) {
+			np, ok := n.Properties[prop]
+			if !ok {
+				continue
+			}
+			op := o.Properties[prop]
+			field := "property " + prop
+
+			if a, b := propertyTypeName(op), propertyTypeName(np); a != b {
+				detail := fmt.Sprintf("type: %s -> %s", a, b)
+				breaking = append(breaking, Change{Kind: "changed", Path: ref, Field: field, Detail: detail, Reason: "consumers expect a value of the old type"})
+			}
+			if detail := enumChange(op.Enums, np.Enums); detail != "" {
+				breaking = append(breaking, Change{Kind: "changed", Path: ref, Field: field, Detail: detail, Reason: "consumers may use a value no longer allowed"})
+			}
+		}
+	}
+
+	return breaking
+}
+
+// breakingParameters returns the breaking changes to the parameters of an operation.
+func breakingParameters(path, verb string, old, new []Parameter) []Change {
+	var breaking []Change
+	change := func(p Parameter, kind, detail, reason string) {
+		breaking = append(breaking, Change{Kind: kind, Path: path, Verb: verb, Field: parameterField(p), Detail: detail, Reason: reason})
+	}
+
+	for _, o := range old {
+		n, ok := findParameter(new, o.Name, o.In)
+		if !ok {
+			continue
+		}
+
+		if !o.Required && n.Required {
+			change(o, "changed", "required: false -> true", "clients may not send the parameter")
+		}
+		if a, b := typeName(o.Schema), typeName(n.Schema); a != b {
+			change(o, "changed", fmt.Sprintf("type: %s -> %s", a, b), "clients send a value of the old type")
+		}
+		if detail := enumChange(o.Enums, n.Enums); detail != "" {
+			change(o, "changed", detail, "clients may send a value no longer allowed")
+		}
+	}
+
+	for _, n := range new {
+		if _, ok := findParameter(old, n.Name, n.In); !ok && n.Required {
+			change(n, "added", "", "clients do not send the new required parameter")
+		}
+	}
+
+	return breaking
+}
+
+// enumChange describes how the enumerated values new narrow those of old, such as "enum: removed [\"b\"]", or returns "" if they do not.
+// An enum where there was none narrows the values allowed to those of the enum.
+func enumChange(old, new Enum) string {
+	if len(old) < 1 && len(new) > 0 {
+		return fmt.Sprintf("enum: added %v", new.values())
+	}
+	if removed := removedEnums(old, new); len(removed) > 0 {
+		return fmt.Sprintf("enum: removed %v", removed)
+	}
+	return ""
+}
+
+// removedEnums returns the values of old absent from new, as JSON text.
+// If new is empty, any value is allowed and none are removed.
+func removedEnums(old, new Enum) []string {
+	if len(new) < 1 {
+		return nil
+	}
+
+	var removed []string
+	for _, e := range old.values() {
+		if !contains(new.values(), e) {
+			removed = append(removed, e)
+		}
+	}
+	return removed
+}
+
+// propertyTypeName describes the type of a property, as typeName does for a Schema.
+func propertyTypeName(p Property) string {
+	if p.Type.Primary() == "array" && p.Items != nil {
+		return "[]" + typeName(*p.Items)
+	}
+	return typeName(Schema{Type: p.Type, Ref: p.Ref})
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBreakingChanges(t *testing.T) {
+	old, err := ParseString(diffOld)
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := ParseString(diffNew)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Additions, and removals of optional parameters, are not breaking
+	want := []string{
+		"/owners: removed (clients calling the path will fail)",
+		"get /pets: response 404: removed (clients may rely on the response)",
+		"get /pets: parameter limit in query: changed: required: false -> true (clients may not send the parameter)",
+		`get /pets: parameter status in query: changed: enum: removed ["sold"] (clients may send a value no longer allowed)`,
+		"get /pets: parameter page in query: changed: type: integer -> string (clients send a value of the old type)",
+		"get /pets: parameter tag in query: added (clients do not send the new required parameter)",
+		"delete /pets: removed (clients calling the operation will fail)",
+		"#/components/schemas/Pet: property id: changed: type: integer -> string (consumers expect a value of the old type)",
+		`#/components/schemas/Pet: property kind: changed: enum: removed ["dog"] (consumers may use a value no longer allowed)`,
+		`#/components/schemas/Pet: property name: changed: enum: added ["Rex"] (consumers may use a value no longer allowed)`,
+	}
+
+	var got []string
+	for _, c := range BreakingChanges(old, new) {
+		got = append(got, c.String())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected breaking changes:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	if breaking := BreakingChanges(new, new); len(breaking) > 0 {
+		t.Errorf("an API breaks itself: %v", breaking)
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"strings"
+)
+
+// AddOperation sets the operation at path and verb, such as "get", to m, replacing any operation already there.
+// The verb is lowered, and Paths and the path's PathItem are created as needed, so the zero API may be built upon.
+func (a *API) AddOperation(path, verb string, m Method) {
+	if a.Paths == nil {
+		a.Paths = make(map[string]PathItem)
+	}
+
+	item := a.Paths[path]
+	if item.Methods == nil {
+		item.Methods = make(map[string]Method)
+	}
+	item.Methods[strings.ToLower(verb)] = m
+	a.Paths[path] = item
+}
+
+// AddComponentSchema sets the schema name in Components to t, replacing any schema already there.
+// Components and its schemas are created as needed, so the zero API may be built upon.
+// The schema may be referred to as "#/components/schemas/" followed by name.
+func (a *API) AddComponentSchema(name string, t Type) {
+	if a.Components == nil {
+		a.Components = make(map[string]map[string]Type)
+	}
+	if a.Components["schemas"] == nil {
+		a.Components["schemas"] = make(map[string]Type)
+	}
+	a.Components["schemas"][name] = t
+}
+
+// AddComponentParameter sets the parameter name in Parameters to p, replacing any parameter already there, creating Parameters as needed.
+func (a *API) AddComponentParameter(name string, p Parameter) {
+	if a.Parameters == nil {
+		a.Parameters = make(map[string]Parameter)
+	}
+	a.Parameters[name] = p
+}
+
+// AddComponentResponse sets the response name in Responses to r, replacing any response already there, creating Responses as needed.
+func (a *API) AddComponentResponse(name string, r Response) {
+	if a.Responses == nil {
+		a.Responses = make(map[string]Response)
+	}
+	a.Responses[name] = r
+}
@@ -0,0 +1,308 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// GenerateClient writes a Go client for the API, in package pkg, to w.
+// The client is a Client type, created by NewClient, with a method per operation named after its operationId.
+// Each method takes a context, the path, query, header, and cookie parameters of the operation, and the request body, if any.
+// Cookie parameters are sent in the Cookie header, as by http.Request.AddCookie.
+// Optional parameters are pointers, or slices, and are omitted if nil.
+// Each method returns the decoded body of the operation's lowest 2xx response, if it has JSON content.
+// Schemas are mapped to Go types as by GenerateGoTypes, whose output is expected in the same package.
+//
+// The following are not supported:
+//   - Operations without an operationId are skipped
+//   - Parameter styles are ignored, arrays are sent as repeated query parameters, or comma-separated headers or cookies
+//   - Request and response bodies other than "application/json" are ignored
+//   - Responses other than the lowest 2xx are not decoded, other statuses produce an error
+func (a API) GenerateClient(pkg string, w io.Writer) error {
+	g := a.goGenerator()
+	g.imports["context"] = true
+	g.imports["fmt"] = true
+	g.imports["net/http"] = true
+
+	var body bytes.Buffer
+	title := a.Info.Title
+	if title == "" {
+		title = "API"
+	}
+	fmt.Fprintf(&body, `// Client calls the %s.
+type Client struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewClient returns a Client for the API at baseURL, such as "https://example.com/api".
+// Requests are made with client, or http.DefaultClient if nil.
+func NewClient(baseURL string, client *http.Client) *Client {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &Client{baseURL: baseURL, client: client}
+}
+
+`, title)
+
+	var err error
+	a.ForEachOperation(func(path, verb string, m Method) {
+		if err != nil || m.OperationID == "" {
+			return
+		}
+		err = a.writeClientMethod(g, &body, path, verb, m)
+	})
+	if err != nil {
+		return err
+	}
+
+	return g.write(w, pkg, body.Bytes())
+}
+
+// clientParameter is a parameter of a generated client method.
+type clientParameter struct {
+	Parameter
+	ident string // Go identifier of the argument
+	typ   string // Go type of the argument
+}
+
+// writeClientMethod writes the client method for an operation.
+func (a API) writeClientMethod(g *goGenerator, b *bytes.Buffer, path, verb string, m Method) error {
+	name := goName(m.OperationID)
+
+	var params []clientParameter
+	used := map[string]bool{"c": true, "ctx": true, "body": true}
+	for _, p := range a.EffectiveParameters(path, verb) {
+		if p.Ref != "" {
+			return fmt.Errorf("%s %s: %s: no such parameter", verb, path, p.Ref)
+		}
+		switch p.Location() {
+		case "path", "query", "header", "cookie":
+		default:
+			continue
+		}
+		p.In = p.Location()
+
+		cp := clientParameter{Parameter: p, ident: goIdent(p.Name), typ: g.goType(p.Schema.property())}
+		for used[cp.ident] || isReservedIdent(cp.ident) {
+			cp.ident += "Param"
+		}
+		used[cp.ident] = true
+		if !p.Required && !strings.HasPrefix(cp.typ, "[]") && !strings.HasPrefix(cp.typ, "map[") && cp.typ != "interface{}" {
+			cp.typ = "*" + cp.typ
+		}
+		params = append(params, cp)
+	}
+
+	rb := m.RequestBody
+	if rb.Ref != "" {
+		var err error
+		if rb, err = a.ResolveRequestBodyRef(rb.Ref); err != nil {
+			return fmt.Errorf("%s %s: request body: %w", verb, path, err)
+		}
+	}
+
+	var bodyType string
+	if mt, ok := rb.Content["application/json"]; ok {
+		bodyType = g.goType(mt.Schema.property())
+	}
+
+	resultType, err := a.clientResult(g, m)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", verb, path, err)
+	}
+
+	// Signature
+	if m.Summary != "" {
+		fmt.Fprintf(b, "// %s calls %s %s: %s.\n", name, strings.ToUpper(verb), path, strings.TrimSuffix(strings.Join(strings.Fields(m.Summary), " "), "."))
+	} else {
+		fmt.Fprintf(b, "// %s calls %s %s.\n", name, strings.ToUpper(verb), path)
+	}
+	if m.Deprecated {
+		b.WriteString("//\n// Deprecated: the operation is deprecated.\n")
+	}
+	args := []string{"ctx context.Context"}
+	for _, p := range params {
+		args = append(args, p.ident+" "+p.typ)
+	}
+	if bodyType != "" {
+		args = append(args, "body "+bodyType)
+	}
+	results := "error"
+	zero := "err"
+	if resultType != "" {
+		results = "(*" + resultType + ", error)"
+		zero = "nil, err"
+	}
+	fmt.Fprintf(b, "func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), results)
+
+	// URL, with path parameters substituted, and templated segments without a parameter kept as-is
+	url := []string{"c.baseURL"}
+	literal, last := "", 0
+	for _, match := range templatePattern.FindAllStringSubmatchIndex(path, -1) {
+		literal += path[last:match[0]]
+		last = match[1]
+
+		p, ok := findClientParameter(params, path[match[2]:match[3]], "path")
+		if !ok {
+			literal += path[match[0]:match[1]]
+			continue
+		}
+		if literal != "" {
+			url = append(url, fmt.Sprintf("%q", literal))
+			literal = ""
+		}
+		g.imports["net/url"] = true
+		url = append(url, fmt.Sprintf("url.PathEscape(fmt.Sprint(%s))", deref(p)))
+	}
+	if literal += path[last:]; literal != "" {
+		url = append(url, fmt.Sprintf("%q", literal))
+	}
+	fmt.Fprintf(b, "u := %s\n", strings.Join(url, " + "))
+
+	// Query parameters
+	var query []clientParameter
+	for _, p := range params {
+		if p.In == "query" {
+			query = append(query, p)
+		}
+	}
+	if len(query) > 0 {
+		g.imports["net/url"] = true
+		b.WriteString("q := url.Values{}\n")
+		for _, p := range query {
+			writeClientValue(b, p, func(v string) string { return fmt.Sprintf("q.Add(%q, fmt.Sprint(%s))", p.Name, v) })
+		}
+		b.WriteString("if len(q) > 0 {\nu += \"?\" + q.Encode()\n}\n")
+	}
+
+	// Request
+	reader := "nil"
+	if bodyType != "" {
+		g.imports["bytes"] = true
+		g.imports["encoding/json"] = true
+		fmt.Fprintf(b, "b, err := json.Marshal(body)\nif err != nil {\nreturn %s\n}\n", zero)
+		reader = "bytes.NewReader(b)"
+	}
+	fmt.Fprintf(b, "req, err := http.NewRequestWithContext(ctx, %q, u, %s)\nif err != nil {\nreturn %s\n}\n", strings.ToUpper(verb), reader, zero)
+	if bodyType != "" {
+		b.WriteString("req.Header.Set(\"Content-Type\", \"application/json\")\n")
+	}
+	if resultType != "" {
+		b.WriteString("req.Header.Set(\"Accept\", \"application/json\")\n")
+	}
+	for _, p := range params {
+		switch p.In {
+		case "header":
+			if strings.HasPrefix(p.typ, "[]") {
+				g.imports["strings"] = true
+				fmt.Fprintf(b, "if len(%s) > 0 {\nvar values []string\nfor _, v := range %s {\nvalues = append(values, fmt.Sprint(v))\n}\nreq.Header.Set(%q, strings.Join(values, \",\"))\n}\n", p.ident, p.ident, p.Name)
+				continue
+			}
+			writeClientValue(b, p, func(v string) string { return fmt.Sprintf("req.Header.Set(%q, fmt.Sprint(%s))", p.Name, v) })
+
+		case "cookie":
+			if strings.HasPrefix(p.typ, "[]") {
+				g.imports["strings"] = true
+				fmt.Fprintf(b, "if len(%s) > 0 {\nvar values []string\nfor _, v := range %s {\nvalues = append(values, fmt.Sprint(v))\n}\nreq.AddCookie(&http.Cookie{Name: %q, Value: strings.Join(values, \",\")})\n}\n", p.ident, p.ident, p.Name)
+				continue
+			}
+			writeClientValue(b, p, func(v string) string {
+				return fmt.Sprintf("req.AddCookie(&http.Cookie{Name: %q, Value: fmt.Sprint(%s)})", p.Name, v)
+			})
+		}
+	}
+
+	// Response
+	fmt.Fprintf(b, "resp, err := c.client.Do(req)\nif err != nil {\nreturn %s\n}\ndefer resp.Body.Close()\n\n", zero)
+	fmt.Fprintf(b, "if resp.StatusCode < 200 || resp.StatusCode > 299 {\nreturn %sfmt.Errorf(\"%s %s: unexpected status %%s\", resp.Status)\n}\n", strings.TrimSuffix(zero, "err"), verb, path)
+	if resultType == "" {
+		b.WriteString("return nil\n}\n\n")
+		return nil
+	}
+	g.imports["encoding/json"] = true
+	fmt.Fprintf(b, "\nvar out %s\nif err := json.NewDecoder(resp.Body).Decode(&out); err != nil {\nreturn nil, err\n}\nreturn &out, nil\n}\n\n", resultType)
+
+	return nil
+}
+
+// clientResult returns the Go type of the JSON content of the lowest 2xx response of m, if any.
+func (a API) clientResult(g *goGenerator, m Method) (string, error) {
+	for _, code := range sortedKeys(m.Responses) {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+
+		r, err := a.ResolveResponse(m.Responses[code])
+		if err != nil {
+			return "", err
+		}
+
+		if mt, ok := r.Content["application/json"]; ok {
+			return g.goType(mt.Schema.property()), nil
+		}
+		return "", nil
+	}
+
+	return "", nil
+}
+
+// writeClientValue writes the statement set, for the value of an optional parameter if present, or for each element of a slice.
+func writeClientValue(b *bytes.Buffer, p clientParameter, set func(v string) string) {
+	switch {
+	case strings.HasPrefix(p.typ, "[]"):
+		fmt.Fprintf(b, "for _, v := range %s {\n%s\n}\n", p.ident, set("v"))
+	case strings.HasPrefix(p.typ, "*"):
+		fmt.Fprintf(b, "if %s != nil {\n%s\n}\n", p.ident, set("*"+p.ident))
+	default:
+		fmt.Fprintf(b, "%s\n", set(p.ident))
+	}
+}
+
+// findClientParameter returns the parameter with the given name and location.
+func findClientParameter(params []clientParameter, name, in string) (clientParameter, bool) {
+	for _, p := range params {
+		if p.Name == name && p.In == in {
+			return p, true
+		}
+	}
+	return clientParameter{}, false
+}
+
+// deref returns the expression for the value of a parameter, dereferencing it if optional.
+func deref(p clientParameter) string {
+	if strings.HasPrefix(p.typ, "*") {
+		return "*" + p.ident
+	}
+	return p.ident
+}
+
+// goIdent converts name to an unexported Go identifier, such as "pet-id" to "petId".
+func goIdent(name string) string {
+	r := []rune(goName(name))
+	for i := 0; i < len(r) && unicode.IsUpper(r[i]); i++ {
+		// Lower the leading run of capitals, except the first of a following word, as in "URLPath" to "urlPath"
+		if i > 0 && i+1 < len(r) && unicode.IsLower(r[i+1]) {
+			break
+		}
+		r[i] = unicode.ToLower(r[i])
+	}
+	return string(r)
+}
+
+// isReservedIdent reports whether s is a Go keyword, or a name used by the generated client methods.
+func isReservedIdent(s string) bool {
+	return contains([]string{
+		"break", "case", "chan", "const", "continue", "default", "defer", "else", "fallthrough", "for", "func",
+		"go", "goto", "if", "import", "interface", "map", "package", "range", "return", "select", "struct",
+		"switch", "type", "var", "err", "req", "resp", "u", "q", "b", "out", "url", "fmt", "http", "json",
+		"bytes", "strings", "context", "values", "v",
+	}, s)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+func TestGenerateClient(t *testing.T) {
+	api, err := ParseString(testSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var client, typ bytes.Buffer
+	if err := api.GenerateClient("pets", &client); err != nil {
+		t.Fatal(err)
+	}
+	if err := api.GenerateGoTypes("pets", &typ); err != nil {
+		t.Fatal(err)
+	}
+
+	// The client and the types it uses, generated to the same package, compile together
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for name, src := range map[string][]byte{"client.go": client.Bytes(), "types.go": typ.Bytes()} {
+		f, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		if err != nil {
+			t.Fatalf("%v\n%s", err, src)
+		}
+		files = append(files, f)
+	}
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check("pets", fset, files, nil)
+	if err != nil {
+		t.Fatalf("generated client does not compile: %v\n%s", err, client.Bytes())
+	}
+
+	tests := []struct {
+		method    string
+		signature string
+		doc       string
+	}{
+		{"GetPet", "func(ctx context.Context, id int, verbose *bool) (*pets.Pet, error)", "// GetPet calls GET /pets/{id}: Get a pet.\n"},
+		{"PutPet", "func(ctx context.Context, id int, body pets.Pet) error", "// PutPet calls PUT /pets/{id}.\n"},
+	}
+
+	c := pkg.Scope().Lookup("Client")
+	if c == nil {
+		t.Fatal("no Client type")
+	}
+	for _, test := range tests {
+		m, _, _ := types.LookupFieldOrMethod(c.Type(), true, pkg, test.method)
+		if m == nil {
+			t.Errorf("%s: no such method", test.method)
+			continue
+		}
+		if got := types.TypeString(m.Type(), nil); got != test.signature {
+			t.Errorf("%s: expected signature %s, got %s", test.method, test.signature, got)
+		}
+		if !strings.Contains(client.String(), test.doc) {
+			t.Errorf("%s: expected doc comment %q", test.method, test.doc)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+)
+
+// Clone returns a deep copy of the API, sharing no maps, slices, or pointers with it, so that either may be modified without affecting the other.
+func (a API) Clone() API {
+	return rewrite(reflect.ValueOf(a), func(reflect.Value) {}).Interface().(API)
+}
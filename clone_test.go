@@ -0,0 +1,62 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(a *API)
+	}{
+		{"info extension", func(a *API) { a.Info.Extensions["x-audience"][1] = 'X' }},
+		{"server", func(a *API) { a.Servers[0].URL = "http://localhost" }},
+		{"operation", func(a *API) {
+			m := a.Paths["/pets/{id}"].Methods["get"]
+			m.Tags[0] = "animals"
+			m.Responses["200"].Headers["X-Rate-Limit"] = Header{Description: "changed"}
+		}},
+		{"path parameter", func(a *API) { a.Paths["/pets/{id}"].Parameters[0].Ref = "" }},
+		{"schema property", func(a *API) { a.Components["schemas"]["Pet"].Properties["id"] = Property{} }},
+		{"schema required", func(a *API) { a.Components["schemas"]["Pet"].Required[0] = "name" }},
+		{"schema example", func(a *API) { a.Components["schemas"]["Pet"].Example[0] = '[' }},
+		{"schema enum", func(a *API) { a.Components["schemas"]["Status"].Enums[0][1] = 'X' }},
+		{"schema items", func(a *API) { a.Components["schemas"]["Tags"].Items.Type[0] = "integer" }},
+		{"schema constraint", func(a *API) { *a.Components["schemas"]["Tags"].MaxItems = 1 }},
+		{"new schema", func(a *API) { a.Components["schemas"]["Owner"] = Type{} }},
+		{"parameter component", func(a *API) { a.Parameters["Id"].Schema.Type[0] = "string" }},
+		{"header component", func(a *API) { a.Headers["RateLimit"].Schema.Type[0] = "string" }},
+		{"link component", func(a *API) { a.Links["Owner"].Parameters["id"] = "$request.path.id" }},
+		{"other component", func(a *API) { a.OtherComponents["x-internal"][0] = 'f' }},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			original, err := ParseString(testSpec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := ParseString(testSpec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			clone := original.Clone()
+			if !reflect.DeepEqual(clone, original) {
+				t.Fatalf("clone differs from the original:\n%+v\n%+v", clone, original)
+			}
+
+			test.mutate(&clone)
+			if !reflect.DeepEqual(original, want) {
+				t.Errorf("mutating the clone changed the original:\n%+v\n%+v", original, want)
+			}
+			if reflect.DeepEqual(clone, original) {
+				t.Errorf("mutation did not change the clone")
+			}
+		})
+	}
+}
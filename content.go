@@ -0,0 +1,48 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"mime"
+	"strings"
+)
+
+// Match returns the MediaType for contentType, such as the value of a Content-Type header.
+// Media types are compared without regard to case or parameters, such as "; charset=utf-8".
+// The most specific match is returned: a key equal to contentType including its parameters,
+// then a key for the same media type, then a wildcard key for its type, such as "application/*", then "*/*".
+func (c Content) Match(contentType string) (MediaType, bool) {
+	mediaType := normalizeMediaType(contentType)
+
+	candidates := []func(key string) bool{
+		func(key string) bool {
+			return strings.EqualFold(strings.TrimSpace(key), strings.TrimSpace(contentType))
+		},
+		func(key string) bool { return normalizeMediaType(key) == mediaType },
+	}
+	if i := strings.IndexByte(mediaType, '/'); i >= 0 {
+		candidates = append(candidates, func(key string) bool { return normalizeMediaType(key) == mediaType[:i]+"/*" })
+	}
+	candidates = append(candidates, func(key string) bool { return normalizeMediaType(key) == "*/*" })
+
+	keys := sortedKeys(c)
+	for _, matches := range candidates {
+		for _, key := range keys {
+			if matches(key) {
+				return c[key], true
+			}
+		}
+	}
+
+	return MediaType{}, false
+}
+
+// normalizeMediaType returns the media type of contentType in lower case, without parameters.
+func normalizeMediaType(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.Split(contentType, ";")[0]
+	}
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
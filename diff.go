@@ -0,0 +1,200 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Change is a single difference between two versions of an API.
+type Change struct {
+	Kind   string // One of "added", "removed", or "changed"
+	Path   string // Path changed, or containing the change
+	Verb   string // HTTP verb of the operation changed, or containing the change, if any
+	Field  string // What changed within the operation, such as "parameter id in query" or "response 404", if any
+	Detail string // What changed about Field, such as "required: false -> true", if any
+	Reason string // Why the change breaks consumers, as set by BreakingChanges
+}
+
+// String formats the change as a single changelog line, such as "get /pets: parameter id in query: changed: required: false -> true".
+// The reason, if any, follows in parentheses.
+func (c Change) String() string {
+	parts := []string{c.Path}
+	if c.Verb != "" {
+		parts[0] = c.Verb + " " + c.Path
+	}
+	if c.Field != "" {
+		parts = append(parts, c.Field)
+	}
+	parts = append(parts, c.Kind)
+	if c.Detail != "" {
+		parts = append(parts, c.Detail)
+	}
+
+	s := strings.Join(parts, ": ")
+	if c.Reason != "" {
+		s += " (" + c.Reason + ")"
+	}
+	return s
+}
+
+// Changes is the structural difference between two versions of an API, as found by Diff.
+type Changes struct {
+	Paths      []Change // Paths added or removed
+	Operations []Change // Operations added or removed from paths present in both
+	Parameters []Change // Parameters added, removed, or changed in operations present in both
+	Responses  []Change // Response codes added or removed from operations present in both
+}
+
+// All returns every change, ordered by path and verb.
+func (c Changes) All() []Change {
+	var all []Change
+	for _, list := range [][]Change{c.Paths, c.Operations, c.Parameters, c.Responses} {
+		all = append(all, list...)
+	}
+
+	// Stable, so changes to the same operation stay in the order of the fields of Changes
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Path != all[j].Path {
+			return all[i].Path < all[j].Path
+		}
+		return verbLess(all[i].Verb, all[j].Verb)
+	})
+
+	return all
+}
+
+// Empty reports whether there are no changes.
+func (c Changes) Empty() bool {
+	return len(c.Paths)+len(c.Operations)+len(c.Parameters)+len(c.Responses) == 0
+}
+
+// Diff returns the structural differences between old and new.
+// Paths are compared by their template as written, so renaming a path variable is reported as a removal and an addition.
+// Parameters are those of EffectiveParameters, matched by name and location, and compared by whether they are required and deprecated, and by their type and enumerated values.
+func Diff(old, new API) Changes {
+	var c Changes
+
+	for _, path := range old.SortedPaths() {
+		if _, ok := new.Paths[path]; !ok {
+			c.Paths = append(c.Paths, Change{Kind: "removed", Path: path})
+			continue
+		}
+
+		for _, verb := range old.SortedVerbs(path) {
+			m, ok := new.Paths[path].Methods[verb]
+			if !ok {
+				c.Operations = append(c.Operations, Change{Kind: "removed", Path: path, Verb: verb})
+				continue
+			}
+			c.Parameters = append(c.Parameters, diffParameters(path, verb, old.EffectiveParameters(path, verb), new.EffectiveParameters(path, verb))...)
+			c.Responses = append(c.Responses, diffResponses(path, verb, old.Paths[path].Methods[verb].Responses, m.Responses)...)
+		}
+		for _, verb := range new.SortedVerbs(path) {
+			if _, ok := old.Paths[path].Methods[verb]; !ok {
+				c.Operations = append(c.Operations, Change{Kind: "added", Path: path, Verb: verb})
+			}
+		}
+	}
+
+	for _, path := range new.SortedPaths() {
+		if _, ok := old.Paths[path]; !ok {
+			c.Paths = append(c.Paths, Change{Kind: "added", Path: path})
+		}
+	}
+	sort.SliceStable(c.Paths, func(i, j int) bool { return c.Paths[i].Path < c.Paths[j].Path })
+
+	return c
+}
+
+// diffParameters compares the parameters of an operation.
+func diffParameters(path, verb string, old, new []Parameter) []Change {
+	var changes []Change
+
+	for _, o := range old {
+		n, ok := findParameter(new, o.Name, o.In)
+		if !ok {
+			changes = append(changes, Change{Kind: "removed", Path: path, Verb: verb, Field: parameterField(o)})
+			continue
+		}
+
+		for _, d := range []struct {
+			name     string
+			old, new string
+		}{
+			{"required", fmt.Sprint(o.Required), fmt.Sprint(n.Required)},
+			{"deprecated", fmt.Sprint(o.Deprecated), fmt.Sprint(n.Deprecated)},
+			{"type", typeName(o.Schema), typeName(n.Schema)},
+			{"enum", "[" + strings.Join(o.Enums.values(), ", ") + "]", "[" + strings.Join(n.Enums.values(), ", ") + "]"},
+		} {
+			if d.old != d.new {
+				detail := fmt.Sprintf("%s: %s -> %s", d.name, d.old, d.new)
+				changes = append(changes, Change{Kind: "changed", Path: path, Verb: verb, Field: parameterField(o), Detail: detail})
+			}
+		}
+	}
+
+	for _, n := range new {
+		if _, ok := findParameter(old, n.Name, n.In); !ok {
+			changes = append(changes, Change{Kind: "added", Path: path, Verb: verb, Field: parameterField(n)})
+		}
+	}
+
+	return changes
+}
+
+// diffResponses compares the response codes of an operation.
+func diffResponses(path, verb string, old, new map[string]Response) []Change {
+	var changes []Change
+	for _, code := range sortedKeys(old) {
+		if _, ok := new[code]; !ok {
+			changes = append(changes, Change{Kind: "removed", Path: path, Verb: verb, Field: "response " + code})
+		}
+	}
+	for _, code := range sortedKeys(new) {
+		if _, ok := old[code]; !ok {
+			changes = append(changes, Change{Kind: "added", Path: path, Verb: verb, Field: "response " + code})
+		}
+	}
+
+	return changes
+}
+
+// verbLess reports whether operations on verb a sort before those on verb b, as in sortedVerbs.
+// The empty verb, for changes to a path itself, sorts first.
+func verbLess(a, b string) bool {
+	rank := func(verb string) int {
+		if verb == "" {
+			return -1
+		}
+		for i, v := range verbs {
+			if v == verb {
+				return i
+			}
+		}
+		return len(verbs)
+	}
+
+	if rank(a) != rank(b) {
+		return rank(a) < rank(b)
+	}
+	return a < b
+}
+
+// parameterField names a parameter in the Field of a Change.
+func parameterField(p Parameter) string {
+	return fmt.Sprintf("parameter %s in %s", p.Name, p.In)
+}
+
+// findParameter returns the parameter with the given name and location.
+func findParameter(params []Parameter, name, in string) (Parameter, bool) {
+	for _, p := range params {
+		if p.Name == name && p.In == in {
+			return p, true
+		}
+	}
+	return Parameter{}, false
+}
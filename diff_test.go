@@ -0,0 +1,87 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// diffOld and diffNew are two versions of an API, differing in each way Diff and BreakingChanges report.
+const (
+	diffOld = `{
+		"paths": {
+			"/pets": {
+				"parameters": [{"name": "limit", "in": "query", "schema": {"type": "integer"}}],
+				"get": {"parameters": [
+						{"name": "status", "in": "query", "schema": {"type": "string", "enum": ["available", "sold"]}},
+						{"name": "page", "in": "query", "schema": {"type": "integer"}},
+						{"name": "sort", "in": "query", "schema": {"type": "string"}}],
+					"responses": {"200": {"description": "ok"}, "404": {"description": "none"}}},
+				"delete": {"responses": {"204": {"description": "ok"}}}
+			},
+			"/owners": {"get": {"responses": {"200": {"description": "ok"}}}}
+		},
+		"components": {"schemas": {"Pet": {"type": "object", "properties": {
+			"id": {"type": "integer"},
+			"kind": {"type": "string", "enum": ["cat", "dog"]},
+			"name": {"type": "string"}}}}}
+	}`
+	diffNew = `{
+		"paths": {
+			"/pets": {
+				"parameters": [{"name": "limit", "in": "query", "required": true, "schema": {"type": "integer"}}],
+				"get": {"parameters": [
+						{"name": "status", "in": "query", "schema": {"type": "string", "enum": ["available"]}},
+						{"name": "page", "in": "query", "schema": {"type": "string"}},
+						{"name": "tag", "in": "query", "required": true, "schema": {"type": "string"}}],
+					"responses": {"200": {"description": "ok"}, "500": {"description": "error"}}},
+				"post": {"responses": {"201": {"description": "ok"}}}
+			},
+			"/stores": {"get": {"responses": {"200": {"description": "ok"}}}}
+		},
+		"components": {"schemas": {"Pet": {"type": "object", "properties": {
+			"id": {"type": "string"},
+			"kind": {"type": "string", "enum": ["cat"]},
+			"name": {"type": "string", "enum": ["Rex"]}}}}}
+	}`
+)
+
+func TestDiff(t *testing.T) {
+	old, err := ParseString(diffOld)
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := ParseString(diffNew)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"/owners: removed",
+		"get /pets: parameter limit in query: changed: required: false -> true",
+		`get /pets: parameter status in query: changed: enum: ["available", "sold"] -> ["available"]`,
+		"get /pets: parameter page in query: changed: type: integer -> string",
+		"get /pets: parameter sort in query: removed",
+		"get /pets: parameter tag in query: added",
+		"get /pets: response 404: removed",
+		"get /pets: response 500: added",
+		"post /pets: added",
+		"delete /pets: removed",
+		"/stores: added",
+	}
+
+	var got []string
+	for _, c := range Diff(old, new).All() {
+		got = append(got, c.String())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected changes:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+
+	if c := Diff(old, old); !c.Empty() {
+		t.Errorf("an API differs from itself: %v", c.All())
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+)
+
+// Enums returns the enumerated values of every schema and property in the API which declares any, including component schemas and those within operations.
+// Each is keyed by the JSON pointer at which the schema appears, such as "/components/schemas/Status" or "/components/schemas/Pet/properties/kind", and its values are as by Enum.AsStrings, in the order written.
+// Sort the keys, such as with sort.Strings, to visit them in a deterministic order.
+func (a API) Enums() map[string][]string {
+	enums := make(map[string][]string)
+	walk(reflect.ValueOf(a), "", func(v reflect.Value, pointer string) error {
+		if _, e := schemaEnums(v); len(e) > 0 {
+			enums[pointer] = e.AsStrings()
+		}
+		return nil
+	})
+	return enums
+}
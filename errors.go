@@ -0,0 +1,62 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// ParseError is an error decoding a specification, with its position in the input where known.
+// The position is known for syntax errors, such as a missing comma.
+// Errors in well-formed input, such as a number where a string is expected, have no position.
+type ParseError struct {
+	Offset int64 // Byte offset of the error in the input, or -1 if unknown
+	Line   int   // Line of the error, counting from 1, or 0 if unknown
+	Column int   // Column of the error in characters, counting from 1, or 0 if unknown
+	Err    error // Underlying error
+}
+
+// Error implements error, prefixing the error with its position, if known.
+func (e *ParseError) Error() string {
+	if e.Line < 1 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// newParseError wraps the error err in decoding input in a ParseError.
+func newParseError(input []byte, err error) error {
+	pe := &ParseError{Offset: -1, Err: err}
+
+	var syntax *json.SyntaxError
+	if !errors.As(err, &syntax) {
+		return pe
+	}
+
+	pe.locate(input, syntax.Offset)
+
+	return pe
+}
+
+// locate sets the position of e to offset in input.
+// As for a *json.SyntaxError, the offset follows the offending byte.
+func (e *ParseError) locate(input []byte, offset int64) {
+	e.Offset = offset
+	i := int(offset) - 1
+	if i < 0 || i >= len(input) {
+		return
+	}
+
+	e.Line = bytes.Count(input[:i], []byte("\n")) + 1
+	e.Column = utf8.RuneCount(input[bytes.LastIndexByte(input[:i], '\n')+1:i]) + 1
+}
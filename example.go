@@ -0,0 +1,137 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ExampleFor returns a representative JSON value for typ, such as for showing a sample payload.
+// The example or default of a schema is used if present, otherwise a value is synthesized from its type:
+// the first of its enumerated values, "string" for strings, 0 for numbers, false for booleans, an array of one item, or an object of its required properties.
+// References are followed; a reference back to a schema being synthesized, as in a self-referential schema, produces null, or an empty array for the items of an array.
+func (a API) ExampleFor(typ Type) (json.RawMessage, error) {
+	v, err := a.example(typeProperty(typ), nil)
+	if err != nil {
+		return nil, err
+	}
+	return marshal(v)
+}
+
+// ParameterExample returns a representative JSON value for the parameter p, such as for constructing a sample request.
+// The example of the parameter is preferred, then the first of its named examples with a value, then a value from its schema, as by ExampleFor.
+// References to parameters and examples are followed.
+func (a API) ParameterExample(p Parameter) (json.RawMessage, error) {
+	if p.Ref != "" {
+		var err error
+		if p, err = a.ResolveParameterRef(p.Ref); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.Example != nil {
+		return p.Example, nil
+	}
+	for _, name := range sortedKeys(p.Examples) {
+		e := p.Examples[name]
+		if e.Ref != "" {
+			var err error
+			if e, err = a.ResolveExampleRef(e.Ref); err != nil {
+				return nil, fmt.Errorf("example %s: %w", name, err)
+			}
+		}
+		if e.Value != nil {
+			return e.Value, nil
+		}
+	}
+
+	v, err := a.example(p.Schema.property(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return marshal(v)
+}
+
+// example synthesizes a value for p, as described by ExampleFor.
+// Chain holds the references being synthesized.
+func (a API) example(p Property, chain []string) (interface{}, error) {
+	if p.Example != nil {
+		return json.RawMessage(p.Example), nil
+	}
+	if p.Default != nil {
+		return json.RawMessage(p.Default), nil
+	}
+
+	if p.Ref != "" {
+		if contains(chain, p.Ref) {
+			return nil, nil
+		}
+		t, err := a.ResolveRef(p.Ref)
+		if err != nil {
+			return nil, err
+		}
+		return a.example(typeProperty(t), append(chain, p.Ref))
+	}
+
+	if len(p.Enums) > 0 {
+		return p.Enums[0], nil
+	}
+
+	switch p.Type.Primary() {
+	case "string":
+		return "string", nil
+	case "integer", "number":
+		return 0, nil
+	case "boolean":
+		return false, nil
+	case "array":
+		if p.Items == nil || p.Items.Ref != "" && contains(chain, p.Items.Ref) {
+			return []interface{}{}, nil
+		}
+		item, err := a.example(p.Items.property(), chain)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{item}, nil
+	}
+
+	// Any one alternative will do
+	for _, alternatives := range [][]Type{p.OneOf, p.AnyOf} {
+		if len(alternatives) > 0 {
+			return a.example(typeProperty(alternatives[0]), chain)
+		}
+	}
+
+	obj := make(map[string]interface{}, len(p.Required))
+	for _, t := range p.AllOf {
+		v, err := a.example(typeProperty(t), chain)
+		if err != nil {
+			return nil, err
+		}
+		// An example or default of a member is raw JSON, which must be decoded to merge
+		if raw, ok := v.(json.RawMessage); ok {
+			if err := json.Unmarshal(raw, &v); err != nil {
+				return nil, err
+			}
+		}
+		if branch, ok := v.(map[string]interface{}); ok {
+			for name, v := range branch {
+				obj[name] = v
+			}
+		}
+	}
+	for _, name := range p.Required {
+		prop, ok := p.Properties[name]
+		if !ok {
+			continue
+		}
+		v, err := a.example(prop, chain)
+		if err != nil {
+			return nil, err
+		}
+		obj[name] = v
+	}
+	return obj, nil
+}
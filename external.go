@@ -0,0 +1,199 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseFileWithRefs opens the OpenAPI v3 specification file at path and deserializes it to an API, inlining references to other files.
+// A reference such as "./schemas/user.json#/User" is resolved relative to the directory of the file making it, and replaced by the value it points to.
+// References within a referenced file, including those local to it such as "#/Address", are inlined likewise.
+// References local to the file at path are left as-is.
+// Files with a ".yaml" or ".yml" extension are read as YAML, others as JSON.
+// References to URLs are not supported, nor are references which refer back to themselves through other files, as these cannot be inlined.
+func ParseFileWithRefs(path string) (API, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return API{}, err
+	}
+
+	r := &fileResolver{docs: make(map[string]interface{})}
+	doc, err := r.load(abs)
+	if err != nil {
+		return API{}, err
+	}
+
+	resolved, err := r.resolve(doc, abs, false)
+	if err != nil {
+		return API{}, err
+	}
+
+	b, err := marshal(resolved)
+	if err != nil {
+		return API{}, err
+	}
+
+	api, err := ParseBytes(b)
+	if err != nil {
+		return api, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	return api, nil
+}
+
+// fileResolver inlines references to other files, tracking the chain of references being followed.
+type fileResolver struct {
+	docs  map[string]interface{} // Decoded documents by absolute path
+	chain []string               // References currently being inlined, as absolute path and fragment, outermost first
+}
+
+// load returns the decoded document at the absolute path, reading it only once.
+func (r *fileResolver) load(path string) (interface{}, error) {
+	if doc, ok := r.docs[path]; ok {
+		return doc, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		var n yaml.Node
+		if err := yaml.Unmarshal(b, &n); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		var buf bytes.Buffer
+		if err := yamlToJSON(&buf, &n); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		b = buf.Bytes()
+	}
+
+	var doc interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, newParseError(b, err))
+	}
+
+	r.docs[path] = doc
+	return doc, nil
+}
+
+// resolve returns a copy of the decoded JSON value v, from the file at path, with references inlined.
+// Local references are inlined only if external, that is if v is from a referenced file.
+func (r *fileResolver) resolve(v interface{}, path string, external bool) (interface{}, error) {
+	switch n := v.(type) {
+	case map[string]interface{}:
+		if ref, ok := n["$ref"].(string); ok && (external || !strings.HasPrefix(ref, "#")) {
+			return r.inline(ref, path)
+		}
+
+		out := make(map[string]interface{}, len(n))
+		for k, e := range n {
+			var err error
+			if out[k], err = r.resolve(e, path, external); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+
+	case []interface{}:
+		out := make([]interface{}, len(n))
+		for i, e := range n {
+			var err error
+			if out[i], err = r.resolve(e, path, external); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+
+	return v, nil
+}
+
+// inline returns the value ref, made in the file at path, points to, with references inlined.
+func (r *fileResolver) inline(ref, path string) (interface{}, error) {
+	target, fragment := ref, ""
+	if i := strings.IndexByte(ref, '#'); i >= 0 {
+		target, fragment = ref[:i], ref[i+1:]
+	}
+
+	// A single letter is a Windows drive, as in `C:\specs\user.json`
+	if u, err := url.Parse(target); err == nil && len(u.Scheme) > 1 {
+		return nil, fmt.Errorf("%s: %s: references to URLs are not supported", path, ref)
+	}
+	switch {
+	case target == "":
+		target = path
+	case !filepath.IsAbs(target):
+		target = filepath.Join(filepath.Dir(path), filepath.FromSlash(target))
+	}
+
+	key := target + "#" + fragment
+	if contains(r.chain, key) {
+		return nil, fmt.Errorf("circular reference: %s -> %s", strings.Join(r.chain, " -> "), key)
+	}
+
+	doc, err := r.load(target)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", path, ref, err)
+	}
+
+	v, err := lookupPointer(doc, fragment)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s: %w", path, ref, err)
+	}
+
+	r.chain = append(r.chain, key)
+	defer func() { r.chain = r.chain[:len(r.chain)-1] }()
+
+	return r.resolve(v, target, true)
+}
+
+// lookupPointer returns the value at the JSON pointer, which may be percent-encoded as in a URI fragment, within the decoded JSON value doc.
+func lookupPointer(doc interface{}, pointer string) (interface{}, error) {
+	pointer, err := url.PathUnescape(pointer)
+	if err != nil {
+		return nil, err
+	}
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%s: not a JSON pointer", pointer)
+	}
+
+	v := doc
+	for _, token := range pointerTokens(pointer) {
+		switch n := v.(type) {
+		case map[string]interface{}:
+			e, ok := n[token]
+			if !ok {
+				return nil, fmt.Errorf("%s: no such value", pointer)
+			}
+			v = e
+
+		case []interface{}:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, fmt.Errorf("%s: no such value", pointer)
+			}
+			v = n[i]
+
+		default:
+			return nil, fmt.Errorf("%s: no such value", pointer)
+		}
+	}
+
+	return v, nil
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes files, keyed by path relative to dir, creating directories as needed.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestParseFileWithRefs(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"api.json": `{"openapi": "3.0.3", "info": {"title": "t", "version": "1"},
+			"paths": {"/pets": {"get": {"responses": {"200": {"description": "ok",
+				"content": {"application/json": {"schema": {"$ref": "schemas/pet.json#/Pet"}}}}}}}},
+			"components": {"schemas": {"Local": {"$ref": "#/components/schemas/Other"}, "Other": {"type": "string"}}}}`,
+		"schemas/pet.json": `{"Pet": {"type": "object", "properties": {
+				"owner": {"$ref": "../common/owner.yaml#/Owner"},
+				"tag": {"$ref": "#/Tag"}}},
+			"Tag": {"type": "string", "enum": ["a", "b"]}}`,
+		"common/owner.yaml": "Owner:\n  type: object\n  properties:\n    name:\n      type: string\n",
+
+		// Each file refers to the other
+		"cycle/api.json": `{"openapi": "3.0.3", "components": {"schemas": {"A": {"$ref": "a.json#/A"}}}}`,
+		"cycle/a.json":   `{"A": {"type": "object", "properties": {"b": {"$ref": "b.json#/B"}}}}`,
+		"cycle/b.json":   `{"B": {"type": "object", "properties": {"a": {"$ref": "a.json#/A"}}}}`,
+
+		"missing.json": `{"openapi": "3.0.3", "components": {"schemas": {"A": {"$ref": "none.json#/A"}}}}`,
+		"pointer.json": `{"openapi": "3.0.3", "components": {"schemas": {"A": {"$ref": "schemas/pet.json#/Cat"}}}}`,
+		"url.json":     `{"openapi": "3.0.3", "components": {"schemas": {"A": {"$ref": "https://example.com/a.json#/A"}}}}`,
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		api, err := ParseFileWithRefs(filepath.Join(dir, "api.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		pet := api.Paths["/pets"].Methods["get"].Responses["200"].Content["application/json"].Schema
+		checks := []struct {
+			name      string
+			got, want interface{}
+		}{
+			{"inlined", pet.Type, Types{"object"}},
+			{"inlined from a nested directory", pet.Properties["owner"].Properties["name"].Type, Types{"string"}},
+			{"local to the referenced file", len(pet.Properties["tag"].Enums), 2},
+			{"local to the file parsed", api.Components["schemas"]["Local"].Ref, "#/components/schemas/Other"},
+		}
+		for _, c := range checks {
+			if !reflect.DeepEqual(c.got, c.want) {
+				t.Errorf("%s: expected %#v, got %#v", c.name, c.want, c.got)
+			}
+		}
+	})
+
+	tests := []struct {
+		file string
+		err  string // Substring of the expected error
+	}{
+		{"cycle/api.json", "circular reference: " + filepath.Join(dir, "cycle", "a.json") + "#/A -> " + filepath.Join(dir, "cycle", "b.json") + "#/B -> " + filepath.Join(dir, "cycle", "a.json") + "#/A"},
+		{"missing.json", "none.json#/A"},
+		{"pointer.json", "/Cat: no such value"},
+		{"url.json", "references to URLs are not supported"},
+	}
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			_, err := ParseFileWithRefs(filepath.Join(dir, filepath.FromSlash(test.file)))
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("expected error containing %q, got %v", test.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+	"strings"
+)
+
+// FilterByTags returns a copy of the API with only the operations tagged with any of tags.
+// Paths left without operations are removed, as are the components no remaining operation references, directly or transitively.
+// Security schemes are kept, as they are referenced by name rather than by reference.
+// Tags are kept if a remaining operation uses them.
+func (a API) FilterByTags(tags ...string) API {
+	a = a.Clone()
+	out := a
+	out.Paths = make(map[string]PathItem)
+	used := make(map[string]bool)
+	for path, item := range a.Paths {
+		methods := make(map[string]Method)
+		for verb, m := range item.Methods {
+			for _, tag := range m.Tags {
+				if contains(tags, tag) {
+					methods[verb] = m
+					break
+				}
+			}
+		}
+		if len(methods) == 0 {
+			continue
+		}
+
+		for _, m := range methods {
+			for _, tag := range m.Tags {
+				used[tag] = true
+			}
+		}
+		item.Methods = methods
+		out.Paths[path] = item
+	}
+
+	out.Tags = nil
+	for _, t := range a.Tags {
+		if used[t.Name] {
+			out.Tags = append(out.Tags, t)
+		}
+	}
+
+	return out.PruneUnusedComponents()
+}
+
+// UsagesOf returns the operations which reference the component ref, such as "#/components/schemas/Address", directly or through other components.
+// The parameters, request body, and responses of each operation are searched, including the headers and links of responses and the parameters of its PathItem.
+// Operations are in the order of ForEachOperation.
+func (a API) UsagesOf(ref string) []Operation {
+	kind, name, err := splitRef(ref)
+	if err != nil {
+		return nil
+	}
+	ref = componentRef(kind, name)
+
+	var ops []Operation
+	a.ForEachOperation(func(path, verb string, m Method) {
+		if a.reachable([]interface{}{a.Paths[path].Parameters, m})[ref] {
+			ops = append(ops, Operation{Path: path, Verb: verb, Method: m})
+		}
+	})
+	return ops
+}
+
+// PruneUnusedComponents returns a copy of the API without the components which no operation references, directly or transitively.
+// References are followed through the parameters, request bodies, and responses of operations, the headers and links of responses,
+// and through properties, items, composition, and discriminator mappings of schemas.
+// Security schemes are kept, as they are referenced by name rather than by reference, as are OtherComponents, which are not modeled.
+// The API itself is not modified.
+func (a API) PruneUnusedComponents() API {
+	a = a.Clone()
+	used := a.reachable(a.Paths)
+
+	out := a
+	out.Components = nil
+	for kind, components := range a.Components {
+		for name, t := range components {
+			if !used[componentRef(kind, name)] {
+				continue
+			}
+			if out.Components == nil {
+				out.Components = make(map[string]map[string]Type)
+			}
+			if out.Components[kind] == nil {
+				out.Components[kind] = make(map[string]Type)
+			}
+			out.Components[kind][name] = t
+		}
+	}
+
+	out.Parameters = nil
+	for name, p := range a.Parameters {
+		if used[componentRef("parameters", name)] {
+			if out.Parameters == nil {
+				out.Parameters = make(map[string]Parameter)
+			}
+			out.Parameters[name] = p
+		}
+	}
+
+	out.Responses = nil
+	for name, r := range a.Responses {
+		if used[componentRef("responses", name)] {
+			if out.Responses == nil {
+				out.Responses = make(map[string]Response)
+			}
+			out.Responses[name] = r
+		}
+	}
+
+	out.RequestBodies = nil
+	for name, b := range a.RequestBodies {
+		if used[componentRef("requestBodies", name)] {
+			if out.RequestBodies == nil {
+				out.RequestBodies = make(map[string]RequestBody)
+			}
+			out.RequestBodies[name] = b
+		}
+	}
+
+	out.Examples = nil
+	for name, e := range a.Examples {
+		if used[componentRef("examples", name)] {
+			if out.Examples == nil {
+				out.Examples = make(map[string]Example)
+			}
+			out.Examples[name] = e
+		}
+	}
+
+	out.Headers = nil
+	for name, h := range a.Headers {
+		if used[componentRef("headers", name)] {
+			if out.Headers == nil {
+				out.Headers = make(map[string]Header)
+			}
+			out.Headers[name] = h
+		}
+	}
+
+	out.Links = nil
+	for name, l := range a.Links {
+		if used[componentRef("links", name)] {
+			if out.Links == nil {
+				out.Links = make(map[string]Link)
+			}
+			out.Links[name] = l
+		}
+	}
+
+	return out
+}
+
+// reachable returns the set of references to components which v, such as the operations of the API, makes directly or through other components.
+// References are in the form built by componentRef.
+func (a API) reachable(v interface{}) map[string]bool {
+	used := make(map[string]bool)
+	var queue []string
+
+	add := func(ref string) {
+		kind, name, err := splitRef(ref)
+		if err != nil {
+			return
+		}
+		if ref = componentRef(kind, name); !used[ref] {
+			used[ref] = true
+			queue = append(queue, ref)
+		}
+	}
+	collect := func(v interface{}) {
+		walk(reflect.ValueOf(v), "", func(v reflect.Value, _ string) error {
+			switch n := v.Interface().(type) {
+			case Parameter:
+				add(n.Ref)
+			case Response:
+				add(n.Ref)
+			case RequestBody:
+				add(n.Ref)
+			case Example:
+				add(n.Ref)
+			case Header:
+				add(n.Ref)
+			case Link:
+				add(n.Ref)
+			case Discriminator:
+				for _, target := range n.Mapping {
+					if !strings.HasPrefix(target, "#") {
+						target = componentRef("schemas", target)
+					}
+					add(target)
+				}
+			default:
+				add(schemaRef(v))
+			}
+			return nil
+		})
+	}
+
+	collect(v)
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+
+		kind, name, _ := splitRef(ref)
+		switch kind {
+		case "parameters":
+			collect(a.Parameters[name])
+		case "responses":
+			collect(a.Responses[name])
+		case "requestBodies":
+			collect(a.RequestBodies[name])
+		case "examples":
+			collect(a.Examples[name])
+		case "headers":
+			collect(a.Headers[name])
+		case "links":
+			collect(a.Links[name])
+		default:
+			collect(a.Components[kind][name])
+		}
+	}
+
+	return used
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+// headerSpec has a schema used only through a response header component.
+const headerSpec = `{"openapi": "3.0.3", "info": {"title": "t", "version": "1"},
+	"paths": {
+		"/pets": {"get": {"operationId": "listPets", "tags": ["pets"], "responses": {"200": {"description": "ok",
+			"headers": {"X-Rate": {"$ref": "#/components/headers/Rate"}}}}}},
+		"/owners": {"get": {"operationId": "listOwners", "tags": ["owners"], "responses": {"200": {"description": "ok",
+			"headers": {"X-Owner": {"schema": {"$ref": "#/components/schemas/OwnerId"}}},
+			"links": {"pets": {"$ref": "#/components/links/Pets"}}}}}}
+	},
+	"components": {
+		"schemas": {
+			"RateValue": {"type": "integer"},
+			"OwnerId": {"type": "string"},
+			"Unused": {"type": "string"}
+		},
+		"headers": {
+			"Rate": {"schema": {"$ref": "#/components/schemas/RateValue"}},
+			"UnusedHeader": {"schema": {"$ref": "#/components/schemas/Unused"}}
+		},
+		"links": {
+			"Pets": {"operationId": "listPets"},
+			"UnusedLink": {"operationId": "listOwners"}
+		}
+	}
+}`
+
+func TestPruneUnusedComponents(t *testing.T) {
+	api, err := ParseString(headerSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pruned := api.PruneUnusedComponents()
+	if errs := pruned.Validate(); len(errs) > 0 {
+		t.Fatalf("pruned specification is invalid: %v", errs)
+	}
+
+	tests := []struct {
+		ref  string
+		kept bool
+	}{
+		{"#/components/schemas/RateValue", true},
+		{"#/components/schemas/OwnerId", true},
+		{"#/components/schemas/Unused", false},
+		{"#/components/headers/Rate", true},
+		{"#/components/headers/UnusedHeader", false},
+		{"#/components/links/Pets", true},
+		{"#/components/links/UnusedLink", false},
+	}
+	for _, test := range tests {
+		if kept := hasComponent(pruned, test.ref); kept != test.kept {
+			t.Errorf("%s: kept is %v, expected %v", test.ref, kept, test.kept)
+		}
+	}
+}
+
+// hasComponent reports whether the API defines the component ref, such as "#/components/headers/Rate".
+func hasComponent(a API, ref string) bool {
+	kind, name, err := splitRef(ref)
+	if err != nil {
+		return false
+	}
+	if kind == "schemas" {
+		_, ok := a.Components[kind][name]
+		return ok
+	}
+
+	field, ok := a.componentFields()[kind]
+	if !ok {
+		return false
+	}
+	return reflect.ValueOf(field).Elem().MapIndex(reflect.ValueOf(name)).IsValid()
+}
+
+func TestFilterByTags(t *testing.T) {
+	api, err := ParseString(headerSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		tag   string
+		paths []string
+		kept  []string
+		gone  []string
+	}{
+		{
+			tag:   "pets",
+			paths: []string{"/pets"},
+			kept:  []string{"#/components/headers/Rate", "#/components/schemas/RateValue"},
+			gone:  []string{"#/components/schemas/OwnerId", "#/components/links/Pets", "#/components/headers/UnusedHeader"},
+		},
+		{
+			tag:   "owners",
+			paths: []string{"/owners"},
+			kept:  []string{"#/components/schemas/OwnerId", "#/components/links/Pets"},
+			gone:  []string{"#/components/headers/Rate", "#/components/schemas/RateValue", "#/components/links/UnusedLink"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.tag, func(t *testing.T) {
+			filtered := api.FilterByTags(test.tag)
+			if errs := filtered.Validate(); len(errs) > 0 {
+				t.Fatalf("filtered specification is invalid: %v", errs)
+			}
+			if paths := sortedKeys(filtered.Paths); !reflect.DeepEqual(paths, test.paths) {
+				t.Errorf("paths are %v, expected %v", paths, test.paths)
+			}
+			for _, ref := range test.kept {
+				if !hasComponent(filtered, ref) {
+					t.Errorf("%s was removed", ref)
+				}
+			}
+			for _, ref := range test.gone {
+				if hasComponent(filtered, ref) {
+					t.Errorf("%s was kept", ref)
+				}
+			}
+		})
+	}
+}
+
+func TestUsagesOf(t *testing.T) {
+	api, err := ParseString(headerSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		ref string
+		ops []string // Operation IDs using ref
+	}{
+		{"#/components/schemas/RateValue", []string{"listPets"}},
+		{"#/components/headers/Rate", []string{"listPets"}},
+		{"#/components/schemas/OwnerId", []string{"listOwners"}},
+		{"#/components/links/Pets", []string{"listOwners"}},
+		{"#/components/schemas/Unused", nil},
+	}
+
+	for _, test := range tests {
+		var ids []string
+		for _, op := range api.UsagesOf(test.ref) {
+			ids = append(ids, op.Method.OperationID)
+		}
+		if !reflect.DeepEqual(ids, test.ops) {
+			t.Errorf("%s: used by %v, expected %v", test.ref, ids, test.ops)
+		}
+	}
+}
@@ -1,3 +1,5 @@
 module github.com/seh-msft/openapi
 
-go 1.16
+go 1.18
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,362 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"io"
+	"strings"
+	"unicode"
+)
+
+// GenerateGoTypes writes Go type definitions, in package pkg, for each schema in Components to w.
+// Objects become structs with json tags, non-required properties are pointers unless a nil slice or map suffices.
+// References become the name of the referenced type, and schemas which are not objects become named types.
+// Referenced members of allOf are embedded, and the properties of inline members are merged into the struct.
+// Schemas whose names convert to the same Go name are numbered, as PetOwner and PetOwner2.
+// The title and description of schemas and their properties become doc comments.
+// Types are mapped as follows:
+//   - integer: int, or int32 and int64 by format
+//   - number: float64, or float32 by format "float"
+//   - string: string, time.Time by format "date-time", or []byte by format "byte" or "binary"
+//   - boolean: bool
+//   - array: a slice of the items
+//   - object: a struct, a map if only additionalProperties are given, or map[string]interface{} if neither are
+//   - anything else, such as a composition: interface{}
+func (a API) GenerateGoTypes(pkg string, w io.Writer) error {
+	g := a.goGenerator()
+	schemas := a.Components["schemas"]
+
+	var body bytes.Buffer
+	for _, name := range sortedKeys(schemas) {
+		t := schemas[name]
+		doc := fmt.Sprintf("%s is the %q schema", g.names[name], name)
+		if title := strings.Join(strings.Fields(t.Title), " "); title != "" {
+			doc += ": " + strings.TrimSuffix(title, ".")
+		}
+		writeGoComment(&body, doc+".\n\n"+t.Description)
+
+		if (t.Is.Primary() == "object" || t.Is.Primary() == "") && t.Ref == "" && (t.Properties != nil || len(t.AllOf) > 0) {
+			fmt.Fprintf(&body, "type %s %s\n\n", g.names[name], g.object(t.Properties, t.Required, t.AllOf))
+			continue
+		}
+		fmt.Fprintf(&body, "type %s %s\n\n", g.names[name], g.goType(typeProperty(t)))
+	}
+
+	return g.write(w, pkg, body.Bytes())
+}
+
+// goGenerator tracks the state of generating Go source.
+type goGenerator struct {
+	names   map[string]string // Go type names, keyed by schema name
+	imports map[string]bool   // Packages used by the source
+}
+
+// goGenerator returns a goGenerator naming the schemas of the API.
+// Schemas whose names convert to the same Go name, such as "pet-owner" and "pet_owner", are numbered in order of their names, as PetOwner and PetOwner2.
+func (a API) goGenerator() *goGenerator {
+	g := &goGenerator{names: make(map[string]string), imports: make(map[string]bool)}
+	used := make(map[string]bool)
+	for _, name := range sortedKeys(a.Components["schemas"]) {
+		n := goName(name)
+		for i := 2; used[n]; i++ {
+			n = fmt.Sprintf("%s%d", goName(name), i)
+		}
+		used[n] = true
+		g.names[name] = n
+	}
+	return g
+}
+
+// write formats and writes the Go source file for package pkg, with the declarations in body, to w.
+func (g *goGenerator) write(w io.Writer, pkg string, body []byte) error {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated from an OpenAPI specification. DO NOT EDIT.\n\npackage %s\n\n", pkg)
+	if len(g.imports) > 0 {
+		b.WriteString("import (\n")
+		for _, path := range sortedKeys(g.imports) {
+			fmt.Fprintf(&b, "%q\n", path)
+		}
+		b.WriteString(")\n\n")
+	}
+	b.Write(body)
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return fmt.Errorf("format generated source: %w", err)
+	}
+
+	_, err = w.Write(src)
+	return err
+}
+
+// object returns a struct type for properties, embedding the referenced types of allOf.
+// The properties of inline members of allOf, and of their own allOf, become fields of the struct, unless properties has one of the same name.
+func (g *goGenerator) object(properties map[string]Property, required []string, allOf []Type) string {
+	var b strings.Builder
+	b.WriteString("struct {\n")
+
+	properties, required, embedded := flattenAllOf(properties, required, allOf)
+	for _, ref := range embedded {
+		fmt.Fprintf(&b, "%s\n", g.ref(ref))
+	}
+
+	used := make(map[string]bool)
+	for _, name := range sortedKeys(properties) {
+		field := goName(name)
+		for i := 2; used[field]; i++ {
+			field = fmt.Sprintf("%s%d", goName(name), i)
+		}
+		used[field] = true
+
+		typ := g.goType(properties[name])
+		tag := name
+		if !contains(required, name) {
+			tag += ",omitempty"
+			if !strings.HasPrefix(typ, "[]") && !strings.HasPrefix(typ, "map[") && typ != "interface{}" {
+				typ = "*" + typ
+			}
+		}
+		p := properties[name]
+		writeGoComment(&b, p.Title+"\n\n"+p.Description)
+		fmt.Fprintf(&b, "%s %s `json:%q`\n", field, typ, tag)
+	}
+
+	b.WriteString("}")
+	return b.String()
+}
+
+// flattenAllOf merges the properties and required properties of the inline members of allOf into those given, recursively, returning them with the references among allOf.
+// Properties given, or of earlier members, take precedence over those of later members.
+func flattenAllOf(properties map[string]Property, required []string, allOf []Type) (map[string]Property, []string, []string) {
+	var refs []string
+	merged := make(map[string]Property, len(properties))
+	for name, p := range properties {
+		merged[name] = p
+	}
+	required = append([]string(nil), required...)
+
+	for _, t := range allOf {
+		if t.Ref != "" {
+			refs = append(refs, t.Ref)
+			continue
+		}
+
+		props, req, more := flattenAllOf(t.Properties, t.Required, t.AllOf)
+		for name, p := range props {
+			if _, ok := merged[name]; !ok {
+				merged[name] = p
+			}
+		}
+		for _, name := range req {
+			if !contains(required, name) {
+				required = append(required, name)
+			}
+		}
+		refs = append(refs, more...)
+	}
+
+	return merged, required, refs
+}
+
+// writeGoComment writes text to w as a Go comment, a line of comment per line of text.
+// Leading and trailing blank lines are dropped, and an empty text writes nothing.
+func writeGoComment(w io.Writer, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimRight(line, " \t\r"); line == "" {
+			fmt.Fprintf(w, "//\n")
+		} else {
+			fmt.Fprintf(w, "// %s\n", line)
+		}
+	}
+}
+
+// goType returns the Go type for a property.
+func (g *goGenerator) goType(p Property) string {
+	if p.Ref != "" {
+		return g.ref(p.Ref)
+	}
+
+	switch p.Type.Primary() {
+	case "integer", "number", "string", "boolean":
+		// Unknown formats fall back to the type's default
+		typ, _ := scalarGoType(p.Type.Primary(), p.Format)
+		if typ == "time.Time" {
+			g.imports["time"] = true
+		}
+		return typ
+
+	case "array":
+		if p.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + g.goType(p.Items.property())
+
+	case "object", "":
+		if p.Properties != nil {
+			return g.object(p.Properties, p.Required, p.AllOf)
+		}
+		if ap := p.AdditionalProperties; ap != nil && ap.Property != nil {
+			return "map[string]" + g.goType(*ap.Property)
+		}
+		if p.Type.Primary() == "object" {
+			return "map[string]interface{}"
+		}
+	}
+
+	return "interface{}"
+}
+
+// GoType returns the Go type for the type and format of the property, as mapped by GenerateGoTypes.
+// References become the name of the referenced schema, such as "Pet", and arrays a slice of the Go type of their items.
+// An error is returned for an unknown type, or a format unknown for an integer or number, such as integer with format "float".
+// Formats of strings other than those mapped are open-ended, and such strings are string.
+// Inline objects with properties have no Go type name, and are an error.
+func (p Property) GoType() (string, error) {
+	if p.Ref != "" {
+		return (&goGenerator{}).ref(p.Ref), nil
+	}
+
+	switch typ := p.Type.Primary(); typ {
+	case "array":
+		if p.Items == nil {
+			return "[]interface{}", nil
+		}
+		items, err := p.Items.GoType()
+		if err != nil {
+			return "", err
+		}
+		return "[]" + items, nil
+
+	case "object", "":
+		switch {
+		case len(p.Type) > 1 && typ == "":
+			return "", fmt.Errorf("several types %q have no Go type", []string(p.Type))
+		case p.Properties != nil:
+			return "", errors.New("inline object has no Go type name")
+		case p.AdditionalProperties != nil && p.AdditionalProperties.Property != nil:
+			values, err := p.AdditionalProperties.Property.GoType()
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + values, nil
+		case typ == "object":
+			return "map[string]interface{}", nil
+		}
+		return "interface{}", nil
+
+	default:
+		goType, err := scalarGoType(typ, p.Format)
+		if err != nil {
+			return "", err
+		}
+		return goType, nil
+	}
+}
+
+// GoType returns the Go type for the type and format of the schema, as by Property.GoType.
+func (s Schema) GoType() (string, error) {
+	return s.property().GoType()
+}
+
+// scalarGoType returns the Go type for an integer, number, string, or boolean of the given format.
+// For a format unknown for an integer or number, the Go type for no format is returned, along with an error.
+func scalarGoType(typ, format string) (string, error) {
+	switch typ {
+	case "integer":
+		switch format {
+		case "":
+			return "int", nil
+		case "int32", "int64":
+			return format, nil
+		}
+		return "int", fmt.Errorf("unknown format %q for type %q", format, typ)
+
+	case "number":
+		switch format {
+		case "", "double":
+			return "float64", nil
+		case "float":
+			return "float32", nil
+		}
+		return "float64", fmt.Errorf("unknown format %q for type %q", format, typ)
+
+	case "string":
+		switch format {
+		case "date-time":
+			return "time.Time", nil
+		case "byte", "binary":
+			return "[]byte", nil
+		}
+		return "string", nil
+
+	case "boolean":
+		return "bool", nil
+	}
+
+	return "", fmt.Errorf("unknown type %q", typ)
+}
+
+// ref returns the Go type name for a reference to a schema.
+func (g *goGenerator) ref(ref string) string {
+	name := ref[strings.LastIndex(ref, "/")+1:]
+	if n, ok := g.names[unescapeToken(name)]; ok {
+		return n
+	}
+	return goName(unescapeToken(name))
+}
+
+// property converts a Schema, such as the items of an array, to the equivalent inline Property.
+func (s Schema) property() Property {
+	return Property{
+		Title:       s.Title,
+		Description: s.Description,
+		Type:        s.Type,
+		Format:      s.Format,
+		Nullable:    s.Nullable,
+		Ref:         s.Ref,
+		Items:       s.Items,
+		Enums:       s.Enums,
+		Default:     s.Default,
+		Const:       s.Const,
+		XML:         s.XML,
+		Required:    s.Required,
+		Properties:  s.Properties,
+		Constraints: s.Constraints,
+		Composition: s.Composition,
+		Extensions:  s.Extensions,
+	}
+}
+
+// goName converts name to an exported Go identifier, such as "pet-owner" to "PetOwner".
+// Names which would begin with a digit are prefixed with "X".
+func goName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+
+	s := b.String()
+	if s == "" || unicode.IsDigit([]rune(s)[0]) {
+		s = "X" + s
+	}
+	return s
+}
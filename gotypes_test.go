@@ -0,0 +1,105 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestGenerateGoTypes(t *testing.T) {
+	api, err := ParseString(`{"components": {"schemas": {
+		"pet-owner": {"type": "object", "title": "An owner", "required": ["name"], "properties": {
+			"name": {"type": "string"},
+			"age": {"type": "integer", "format": "int32"},
+			"born": {"type": "string", "format": "date-time"},
+			"pets": {"type": "array", "items": {"$ref": "#/components/schemas/Pet"}}}},
+		"pet_owner": {"type": "string"},
+		"Pet": {"allOf": [{"$ref": "#/components/schemas/Base"},
+			{"type": "object", "properties": {"tags": {"type": "object", "additionalProperties": {"type": "string"}}}}]},
+		"Base": {"type": "object", "description": "Common fields.", "properties": {"id": {"type": "integer", "format": "int64"}}}
+	}}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `// Code generated from an OpenAPI specification. DO NOT EDIT.
+
+package pets
+
+import (
+	"time"
+)
+
+// Base is the "Base" schema.
+//
+// Common fields.
+type Base struct {
+	Id *int64 ` + "`json:\"id,omitempty\"`" + `
+}
+
+// Pet is the "Pet" schema.
+type Pet struct {
+	Base
+	Tags map[string]string ` + "`json:\"tags,omitempty\"`" + `
+}
+
+// PetOwner is the "pet-owner" schema: An owner.
+type PetOwner struct {
+	Age  *int32     ` + "`json:\"age,omitempty\"`" + `
+	Born *time.Time ` + "`json:\"born,omitempty\"`" + `
+	Name string     ` + "`json:\"name\"`" + `
+	Pets []Pet      ` + "`json:\"pets,omitempty\"`" + `
+}
+
+// PetOwner2 is the "pet_owner" schema.
+type PetOwner2 string
+`
+
+	var buf bytes.Buffer
+	if err := api.GenerateGoTypes("pets", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != want {
+		t.Errorf("expected:\n%s\ngot:\n%s", want, buf.String())
+	}
+}
+
+func TestGoType(t *testing.T) {
+	tests := []struct {
+		property string
+		want     string
+		err      bool
+	}{
+		{property: `{"type": "integer"}`, want: "int"},
+		{property: `{"type": "integer", "format": "int64"}`, want: "int64"},
+		{property: `{"type": "number", "format": "float"}`, want: "float32"},
+		{property: `{"type": "number"}`, want: "float64"},
+		{property: `{"type": "string", "format": "byte"}`, want: "[]byte"},
+		{property: `{"type": "string", "format": "uuid"}`, want: "string"},
+		{property: `{"type": "boolean"}`, want: "bool"},
+		{property: `{"type": "array", "items": {"type": "string", "format": "date-time"}}`, want: "[]time.Time"},
+		{property: `{"$ref": "#/components/schemas/Pet"}`, want: "Pet"},
+		{property: `{"type": "integer", "format": "float"}`, err: true},
+		{property: `{"type": "widget"}`, err: true},
+	}
+
+	for _, test := range tests {
+		var p Property
+		if err := json.Unmarshal([]byte(test.property), &p); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := p.GoType()
+		switch {
+		case test.err && err == nil:
+			t.Errorf("%s: expected an error, got %q", test.property, got)
+		case !test.err && err != nil:
+			t.Errorf("%s: unexpected error: %v", test.property, err)
+		case !test.err && got != test.want:
+			t.Errorf("%s: expected %q, got %q", test.property, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,776 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// Marshal serializes an API to compact OpenAPI v3 JSON.
+// Fields the API structure does not model are dropped, as by Write.
+func (a API) Marshal() ([]byte, error) {
+	return marshal(a)
+}
+
+// Write serializes an API to w as indented OpenAPI v3 JSON.
+// Fields the API structure does not model are dropped, so a parsed specification may be written with less than it had.
+// These are notably:
+//   - example, discriminator, additionalProperties, deprecated, readOnly, and writeOnly of a Schema, such as the items of an array or the schema of a body
+//   - externalDocs of a schema, Type, Property, or Schema alike
+//   - style, explode, and examples of a Header, and allowReserved and content of a Parameter
+//   - requestBody and server of a Link
+//   - extensions of objects without an Extensions field, such as SecurityScheme, Example, and License
+//   - summary of the Info, webhooks, and jsonSchemaDialect of OpenAPI 3.1, and keywords of JSON Schema which OpenAPI 3.0 lacks other than const
+func (a API) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	enc := newEncoder(bw)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(a); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// newEncoder returns a JSON encoder which leaves HTML characters in descriptions as-is.
+func newEncoder(w io.Writer) *json.Encoder {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return enc
+}
+
+// marshal is json.Marshal, but leaves HTML characters in descriptions as-is.
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := newEncoder(&buf)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting components with dedicated fields and extensions.
+func (a *API) UnmarshalJSON(data []byte) error {
+	return a.unmarshal(context.Background(), data)
+}
+
+// unmarshal is UnmarshalJSON, failing with ctx.Err() once ctx is done.
+// ctx is checked before each path item, each schema, and each other kind of component is decoded.
+func (a *API) unmarshal(ctx context.Context, data []byte) error {
+	type api API
+	aux := struct {
+		*api
+		Paths      map[string]json.RawMessage `json:"paths"`
+		Components map[string]json.RawMessage `json:"components"`
+	}{api: (*api)(a)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	a.Paths = nil
+	if aux.Paths != nil {
+		a.Paths = make(map[string]PathItem, len(aux.Paths))
+	}
+	for _, path := range sortedKeys(aux.Paths) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var item PathItem
+		if err := json.Unmarshal(aux.Paths[path], &item); err != nil {
+			return err
+		}
+		a.Paths[path] = item
+	}
+
+	a.Components = nil
+	fields := a.componentFields()
+	for _, kind := range sortedKeys(aux.Components) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if field, ok := fields[kind]; ok {
+			if err := json.Unmarshal(aux.Components[kind], field); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if kind != "schemas" {
+			if a.OtherComponents == nil {
+				a.OtherComponents = make(map[string]json.RawMessage)
+			}
+			a.OtherComponents[kind] = aux.Components[kind]
+			continue
+		}
+
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal(aux.Components[kind], &raw); err != nil {
+			return err
+		}
+		var types map[string]Type
+		if raw != nil {
+			types = make(map[string]Type, len(raw))
+		}
+		for _, name := range sortedKeys(raw) {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			var t Type
+			if err := json.Unmarshal(raw[name], &t); err != nil {
+				return err
+			}
+			types[name] = t
+		}
+		if a.Components == nil {
+			a.Components = make(map[string]map[string]Type)
+		}
+		a.Components[kind] = types
+	}
+
+	var err error
+	a.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, emitting components with dedicated fields, an empty security list, and extensions.
+func (a API) MarshalJSON() ([]byte, error) {
+	type api API
+	aux := struct {
+		api
+		Components map[string]interface{} `json:"components,omitempty"`
+		Security   *[]map[string][]string `json:"security,omitempty"`
+	}{api: api(a)}
+	if a.Security != nil {
+		aux.Security = &a.Security
+	}
+
+	for kind, raw := range a.OtherComponents {
+		if aux.Components == nil {
+			aux.Components = make(map[string]interface{})
+		}
+		aux.Components[kind] = raw
+	}
+	for kind, types := range a.Components {
+		if aux.Components == nil {
+			aux.Components = make(map[string]interface{})
+		}
+		aux.Components[kind] = types
+	}
+	for kind, field := range a.componentFields() {
+		if reflect.ValueOf(field).Elem().Len() == 0 {
+			continue
+		}
+		if aux.Components == nil {
+			aux.Components = make(map[string]interface{})
+		}
+		aux.Components[kind] = field
+	}
+
+	b, err := marshal(aux)
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, a.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (i *Info) UnmarshalJSON(data []byte) error {
+	type info Info
+	if err := json.Unmarshal(data, (*info)(i)); err != nil {
+		return err
+	}
+
+	var err error
+	i.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, emitting extensions.
+func (i Info) MarshalJSON() ([]byte, error) {
+	type info Info
+	b, err := marshal(info(i))
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, i.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting operations into Methods and collecting extensions.
+// Every member which is not a field of PathItem or an extension is taken as an operation.
+func (p *PathItem) UnmarshalJSON(data []byte) error {
+	type pathItem PathItem
+	if err := json.Unmarshal(data, (*pathItem)(p)); err != nil {
+		return err
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	fields := jsonFields(pathItemType)
+	for k, raw := range members {
+		if _, ok := fields[k]; ok || strings.HasPrefix(k, "x-") {
+			continue
+		}
+
+		var m Method
+		if err := json.Unmarshal(raw, &m); err != nil {
+			return err
+		}
+		if p.Methods == nil {
+			p.Methods = make(map[string]Method)
+		}
+		p.Methods[k] = m
+	}
+
+	var err error
+	p.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, emitting Methods as members in the order of verbs, then extensions.
+func (p PathItem) MarshalJSON() ([]byte, error) {
+	type pathItem PathItem
+	b, err := marshal(pathItem(p))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := sortedVerbs(p.Methods)
+	methods := make(map[string]json.RawMessage, len(keys))
+	for _, verb := range keys {
+		if methods[verb], err = marshal(p.Methods[verb]); err != nil {
+			return nil, err
+		}
+	}
+
+	if b, err = withMembers(b, keys, methods); err != nil {
+		return nil, err
+	}
+	return withExtensions(b, p.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (m *Method) UnmarshalJSON(data []byte) error {
+	type method Method
+	aux := struct {
+		*method
+		hideJSONMethods
+	}{method: (*method)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	m.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, omitting an empty request body, emitting an empty security list, and emitting extensions.
+// A request body reference is emitted alone.
+func (m Method) MarshalJSON() ([]byte, error) {
+	type method Method
+	aux := struct {
+		method
+		hideJSONMethods
+		RequestBody interface{}            `json:"requestBody,omitempty"`
+		Security    *[]map[string][]string `json:"security,omitempty"`
+	}{method: method(m)}
+	switch {
+	case m.RequestBody.Ref != "":
+		aux.RequestBody = reference{m.RequestBody.Ref}
+	case !isZero(m.RequestBody):
+		aux.RequestBody = &m.RequestBody
+	}
+	if m.Security != nil {
+		aux.Security = &m.Security
+	}
+
+	b, err := marshal(aux)
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, m.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (p *Parameter) UnmarshalJSON(data []byte) error {
+	type parameter Parameter
+	aux := struct {
+		*parameter
+		hideJSONMethods
+	}{parameter: (*parameter)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	var err error
+	p.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, omitting an empty schema and emitting extensions.
+// A reference is emitted alone.
+func (p Parameter) MarshalJSON() ([]byte, error) {
+	if p.Ref != "" {
+		return marshal(reference{p.Ref})
+	}
+
+	type parameter Parameter
+	aux := struct {
+		parameter
+		hideJSONMethods
+		Schema *Schema `json:"schema,omitempty"`
+	}{parameter: parameter(p)}
+	if !isZero(p.Schema) {
+		aux.Schema = &p.Schema
+	}
+
+	b, err := marshal(aux)
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, p.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (r *Response) UnmarshalJSON(data []byte) error {
+	type response Response
+	if err := json.Unmarshal(data, (*response)(r)); err != nil {
+		return err
+	}
+
+	var err error
+	r.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, emitting extensions.
+// A reference is emitted alone.
+func (r Response) MarshalJSON() ([]byte, error) {
+	if r.Ref != "" {
+		return marshal(reference{r.Ref})
+	}
+
+	type response Response
+	b, err := marshal(response(r))
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, r.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (h *Header) UnmarshalJSON(data []byte) error {
+	type header Header
+	if err := json.Unmarshal(data, (*header)(h)); err != nil {
+		return err
+	}
+
+	var err error
+	h.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, omitting an empty schema and emitting extensions.
+// A reference is emitted alone.
+func (h Header) MarshalJSON() ([]byte, error) {
+	if h.Ref != "" {
+		return marshal(reference{h.Ref})
+	}
+
+	type header Header
+	aux := struct {
+		header
+		Schema *Schema `json:"schema,omitempty"`
+	}{header: header(h)}
+	if !isZero(h.Schema) {
+		aux.Schema = &h.Schema
+	}
+
+	b, err := marshal(aux)
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, h.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (t *Type) UnmarshalJSON(data []byte) error {
+	type typ Type
+	if err := json.Unmarshal(data, (*typ)(t)); err != nil {
+		return err
+	}
+
+	var err error
+	t.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, emitting extensions.
+func (t Type) MarshalJSON() ([]byte, error) {
+	type typ Type
+	b, err := marshal(typ(t))
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, t.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (p *Property) UnmarshalJSON(data []byte) error {
+	type property Property
+	if err := json.Unmarshal(data, (*property)(p)); err != nil {
+		return err
+	}
+
+	var err error
+	p.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, emitting extensions.
+func (p Property) MarshalJSON() ([]byte, error) {
+	type property Property
+	b, err := marshal(property(p))
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, p.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	type schema Schema
+	if err := json.Unmarshal(data, (*schema)(s)); err != nil {
+		return err
+	}
+
+	var err error
+	s.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, emitting extensions.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	type schema Schema
+	b, err := marshal(schema(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, s.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (rb *RequestBody) UnmarshalJSON(data []byte) error {
+	type requestBody RequestBody
+	if err := json.Unmarshal(data, (*requestBody)(rb)); err != nil {
+		return err
+	}
+
+	var err error
+	rb.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, emitting extensions.
+func (rb RequestBody) MarshalJSON() ([]byte, error) {
+	type requestBody RequestBody
+	b, err := marshal(requestBody(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, rb.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	type tag Tag
+	if err := json.Unmarshal(data, (*tag)(t)); err != nil {
+		return err
+	}
+
+	var err error
+	t.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, emitting extensions.
+func (t Tag) MarshalJSON() ([]byte, error) {
+	type tag Tag
+	b, err := marshal(tag(t))
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, t.Extensions)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (s *Server) UnmarshalJSON(data []byte) error {
+	type server Server
+	if err := json.Unmarshal(data, (*server)(s)); err != nil {
+		return err
+	}
+
+	var err error
+	s.Extensions, err = extensions(data)
+	return err
+}
+
+// MarshalJSON implements json.Marshaler, emitting extensions.
+func (s Server) MarshalJSON() ([]byte, error) {
+	type server Server
+	b, err := marshal(server(s))
+	if err != nil {
+		return nil, err
+	}
+
+	return withExtensions(b, s.Extensions)
+}
+
+// MarshalJSON implements json.Marshaler, omitting an empty schema.
+func (mt MediaType) MarshalJSON() ([]byte, error) {
+	type mediaType MediaType
+	aux := struct {
+		mediaType
+		Schema *Schema `json:"schema,omitempty"`
+	}{mediaType: mediaType(mt)}
+	if !isZero(mt.Schema) {
+		aux.Schema = &mt.Schema
+	}
+
+	return marshal(aux)
+}
+
+// AsStrings returns the enumerated values as strings.
+// String values are unquoted, values of other types are their JSON text, such as "1" or "true".
+func (e Enum) AsStrings() []string {
+	if e == nil {
+		return nil
+	}
+
+	out := make([]string, len(e))
+	for i, v := range e {
+		v = bytes.TrimSpace(v)
+		if !bytes.HasPrefix(v, []byte(`"`)) || json.Unmarshal(v, &out[i]) != nil {
+			out[i] = string(v)
+		}
+	}
+	return out
+}
+
+// values returns the compact JSON text of each enumerated value, for comparison.
+func (e Enum) values() []string {
+	out := make([]string, len(e))
+	for i, v := range e {
+		var b bytes.Buffer
+		if err := json.Compact(&b, v); err != nil {
+			out[i] = string(v)
+			continue
+		}
+		out[i] = b.String()
+	}
+	return out
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a string or an array of strings.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = Types{s}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// MarshalJSON implements json.Marshaler, emitting a string for a single type and an array otherwise.
+func (t Types) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return marshal(t[0])
+	}
+	return marshal([]string(t))
+}
+
+// Primary returns the type other than "null", such as "string" for ["string", "null"].
+// If there are several such types, or none, Primary returns "".
+func (t Types) Primary() string {
+	primary := ""
+	for _, s := range t {
+		if s == "null" {
+			continue
+		}
+		if primary != "" {
+			return ""
+		}
+		primary = s
+	}
+	return primary
+}
+
+// IsNullable reports whether the types include "null", the OpenAPI 3.1 form of nullable.
+func (t Types) IsNullable() bool {
+	return contains(t, "null")
+}
+
+// IsNullable reports whether the property may be null, in either the OpenAPI 3.0 form, Nullable, or the OpenAPI 3.1 form, a "null" type.
+func (p Property) IsNullable() bool {
+	return p.Nullable || p.Type.IsNullable()
+}
+
+// PrimaryType returns the type of the property other than "null", as by Types.Primary, such as "string" for either "string" or ["string", "null"].
+func (p Property) PrimaryType() string {
+	return p.Type.Primary()
+}
+
+// PrimaryType returns the type of the schema other than "null", as by Types.Primary.
+func (s Schema) PrimaryType() string {
+	return s.Type.Primary()
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a boolean or a number.
+func (e *Exclusive) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &e.Bool); err == nil {
+		e.Value = nil
+		return nil
+	}
+
+	e.Bool = false
+	return json.Unmarshal(data, &e.Value)
+}
+
+// MarshalJSON implements json.Marshaler, emitting Value if set and Bool otherwise.
+func (e Exclusive) MarshalJSON() ([]byte, error) {
+	if e.Value != nil {
+		return marshal(*e.Value)
+	}
+	return marshal(e.Bool)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, accepting a boolean or a schema.
+func (ap *AdditionalProperties) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &ap.Allowed); err == nil {
+		ap.Property = nil
+		return nil
+	}
+
+	ap.Allowed = true
+	return json.Unmarshal(data, &ap.Property)
+}
+
+// MarshalJSON implements json.Marshaler, emitting Property if set and Allowed otherwise.
+func (ap AdditionalProperties) MarshalJSON() ([]byte, error) {
+	if ap.Property != nil {
+		return marshal(ap.Property)
+	}
+	return marshal(ap.Allowed)
+}
+
+// hideJSONMethods, embedded in a struct beside the alias of a type which embeds a Schema or RequestBody, such as Parameter,
+// hides the JSON methods the alias would otherwise be promoted from them, which encode and decode only the embedded value.
+// Fields shadow methods of the same name promoted from deeper within the struct.
+type hideJSONMethods struct {
+	MarshalJSON   struct{} `json:"-"`
+	UnmarshalJSON struct{} `json:"-"`
+}
+
+// reference is the JSON form of a reference object, in place of a definition.
+type reference struct {
+	Ref string `json:"$ref"`
+}
+
+// componentFields returns pointers to the API fields which hold kinds of components other than Type, keyed by kind.
+func (a *API) componentFields() map[string]interface{} {
+	return map[string]interface{}{
+		"securitySchemes": &a.SecuritySchemes,
+		"parameters":      &a.Parameters,
+		"responses":       &a.Responses,
+		"requestBodies":   &a.RequestBodies,
+		"examples":        &a.Examples,
+		"headers":         &a.Headers,
+		"links":           &a.Links,
+	}
+}
+
+// extensions collects the "x-" prefixed members of the JSON object in data.
+// The raw bytes of each member are kept as-is.
+func extensions(data []byte) (map[string]json.RawMessage, error) {
+	// Most objects have no extensions, skip decoding them a second time
+	if !bytes.Contains(data, []byte(`"x-`)) {
+		return nil, nil
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	var ext map[string]json.RawMessage
+	for k, v := range members {
+		if !strings.HasPrefix(k, "x-") {
+			continue
+		}
+		if ext == nil {
+			ext = make(map[string]json.RawMessage)
+		}
+		ext[k] = v
+	}
+
+	return ext, nil
+}
+
+// withExtensions appends the members in ext, sorted by key, to the JSON object in data.
+func withExtensions(data []byte, ext map[string]json.RawMessage) ([]byte, error) {
+	return withMembers(data, sortedKeys(ext), ext)
+}
+
+// withMembers appends the members of the JSON object in values, in the order of keys, to the JSON object in data.
+func withMembers(data []byte, keys []string, values map[string]json.RawMessage) ([]byte, error) {
+	if len(keys) == 0 {
+		return data, nil
+	}
+
+	var buf bytes.Buffer
+	buf.Write(bytes.TrimSuffix(data, []byte("}")))
+	for i, k := range keys {
+		if i > 0 || len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		name, err := marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		if len(values[k]) == 0 {
+			buf.WriteString("null")
+			continue
+		}
+		buf.Write(values[k])
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWriteRoundTrip(t *testing.T) {
+	api, err := ParseString(testSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Extensions are kept on the model, not only in the output
+	extensions := []struct {
+		where string
+		ext   map[string]json.RawMessage
+		key   string
+	}{
+		{"property", api.Components["schemas"]["Pet"].Properties["id"].Extensions, "x-ms-client-name"},
+		{"schema", api.Components["schemas"]["Tags"].Items.Extensions, "x-ms-client-name"},
+		{"component schema", api.Components["schemas"]["Status"].Extensions, "x-ms-enum"},
+		{"request body", api.Paths["/pets/{id}"].Methods["put"].RequestBody.Extensions, "x-ms-requestBody-name"},
+		{"tag", api.Tags[0].Extensions, "x-displayName"},
+		{"server", api.Servers[0].Extensions, "x-ms-parameterized-host"},
+	}
+	for _, e := range extensions {
+		if _, ok := e.ext[e.key]; !ok {
+			t.Errorf("%s: missing extension %s", e.where, e.key)
+		}
+	}
+
+	m := api.Paths["/pets/{id}"].Methods["get"]
+	m.Summary = "Fetch a pet"
+	api.Paths["/pets/{id}"].Methods["get"] = m
+
+	var buf bytes.Buffer
+	if err := api.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	// The written specification is the original but for the summary, with nothing lost
+	var want, got interface{}
+	if err := json.Unmarshal([]byte(testSpec), &want); err != nil {
+		t.Fatal(err)
+	}
+	want.(map[string]interface{})["paths"].(map[string]interface{})["/pets/{id}"].(map[string]interface{})["get"].(map[string]interface{})["summary"] = "Fetch a pet"
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("written specification is not JSON: %v\n%s", err, buf.Bytes())
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("written specification differs:\n%s", buf.Bytes())
+	}
+}
+
+func TestWriteDropsUnmodeled(t *testing.T) {
+	// Each spec has one field, named by dropped, which the API structure does not model, as listed by Write
+	tests := []struct {
+		dropped string
+		spec    string
+	}{
+		{"style", `{"paths": {"/a": {"get": {"responses": {"200": {"description": "ok",
+			"headers": {"X-A": {"style": "simple", "schema": {"type": "string"}}}}}}}}}`},
+		{"allowReserved", `{"paths": {"/a": {"get": {"parameters": [{"name": "q", "in": "query", "allowReserved": true}]}}}}`},
+		{"server", `{"components": {"links": {"L": {"operationId": "a", "server": {"url": "/"}}}}}`},
+		{"readOnly", `{"components": {"schemas": {"A": {"type": "array", "items": {"type": "string", "readOnly": true}}}}}`},
+		{"externalDocs", `{"components": {"schemas": {"A": {"type": "string", "externalDocs": {"url": "/"}}}}}`},
+		{"x-logo", `{"info": {"title": "t", "version": "1", "license": {"name": "MIT", "x-logo": true}}}`},
+		{"webhooks", `{"openapi": "3.1.0", "webhooks": {}}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dropped, func(t *testing.T) {
+			if !strings.Contains(test.spec, `"`+test.dropped+`"`) {
+				t.Fatalf("spec lacks %q", test.dropped)
+			}
+
+			api, err := ParseString(test.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := api.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(b), `"`+test.dropped+`"`) {
+				t.Errorf("%q was kept, update the fields listed by Write:\n%s", test.dropped, b)
+			}
+		})
+	}
+}
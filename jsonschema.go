@@ -0,0 +1,109 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// jsonSchemaDialect is the JSON Schema draft emitted by SchemaJSON.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// SchemaJSON returns the schema name of Components as a standalone JSON Schema (draft 2020-12) document.
+// The schemas it references, directly or transitively, are included under "$defs", and references such as "#/components/schemas/Address" become "#/$defs/Address".
+// The conventions of OpenAPI 3.0 are translated as by NormalizeTo31, so nullable becomes a "null" type.
+// Keywords specific to OpenAPI, such as discriminator and example, and extensions are kept, and are ignored by JSON Schema validators.
+func (a API) SchemaJSON(name string) ([]byte, error) {
+	root, ok := a.Components["schemas"][name]
+	if !ok {
+		return nil, fmt.Errorf("%s: no such component", componentRef("schemas", name))
+	}
+
+	// The transitive closure of the schemas referenced
+	defs := make(map[string]Type)
+	queue := root.refs()
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+
+		kind, def, err := splitRef(ref)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := defs[def]; ok {
+			continue
+		}
+		t, ok := a.Components[kind][def]
+		if kind != "schemas" || !ok {
+			return nil, fmt.Errorf("%s: no such schema", ref)
+		}
+		defs[def] = t
+		queue = append(queue, t.refs()...)
+	}
+
+	// The root schema's members, alongside "$schema" and "$defs"
+	doc := make(map[string]json.RawMessage)
+	b, err := marshal(jsonSchema(root))
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+
+	if doc["$schema"], err = marshal(jsonSchemaDialect); err != nil {
+		return nil, err
+	}
+	if len(defs) > 0 {
+		converted := make(map[string]Type, len(defs))
+		for def, t := range defs {
+			converted[def] = jsonSchema(t)
+		}
+		if doc["$defs"], err = marshal(converted); err != nil {
+			return nil, err
+		}
+	}
+
+	return marshal(doc)
+}
+
+// jsonSchema returns a copy of t with the conventions of OpenAPI 3.0 translated to those of JSON Schema, and references to schemas made to "$defs".
+func jsonSchema(t Type) Type {
+	defsRef := func(ref string) string {
+		if kind, name, err := splitRef(ref); err == nil && kind == "schemas" {
+			return "#/$defs/" + url.PathEscape(escapeToken(name))
+		}
+		return ref
+	}
+
+	return rewrite(reflect.ValueOf(t), func(v reflect.Value) {
+		if !v.CanAddr() {
+			return
+		}
+		switch n := v.Addr().Interface().(type) {
+		case *Type:
+			n.Ref = defsRef(n.Ref)
+			nullableTo31(&n.Is, &n.Nullable)
+			n.Constraints.exclusiveTo31()
+		case *Property:
+			n.Ref = defsRef(n.Ref)
+			nullableTo31(&n.Type, &n.Nullable)
+			n.Constraints.exclusiveTo31()
+		case *Schema:
+			n.Ref = defsRef(n.Ref)
+			nullableTo31(&n.Type, &n.Nullable)
+			n.Constraints.exclusiveTo31()
+		case *Discriminator:
+			for value, target := range n.Mapping {
+				if strings.HasPrefix(target, "#") {
+					n.Mapping[value] = defsRef(target)
+				}
+			}
+		}
+	}).Interface().(Type)
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteMarkdown writes a human-readable Markdown reference for the API to w.
+// Operations are grouped by tag, in the order of Tags followed by any undeclared tags in lexical order.
+// Operations with several tags appear under each, untagged operations appear last under "default".
+// The schemas of Components follow, each headed by its title, if any, with a table of its properties.
+func (a API) WriteMarkdown(w io.Writer) error {
+	var b bytes.Buffer
+
+	title := a.Info.Title
+	if title == "" {
+		title = "API"
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	if a.Info.Version != "" {
+		fmt.Fprintf(&b, "Version %s\n\n", a.Info.Version)
+	}
+	if a.Info.Description != "" {
+		fmt.Fprintf(&b, "%s\n\n", a.Info.Description)
+	}
+
+	if len(a.Servers) > 0 {
+		b.WriteString("## Servers\n\n")
+		for _, s := range a.Servers {
+			if s.Description != "" {
+				fmt.Fprintf(&b, "- `%s` - %s\n", s.URL, s.Description)
+			} else {
+				fmt.Fprintf(&b, "- `%s`\n", s.URL)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	descriptions := make(map[string]string)
+	for _, t := range a.Tags {
+		descriptions[t.Name] = t.Description
+	}
+
+	groups := a.OperationsByTag()
+	for _, tag := range a.markdownOrder(groups) {
+		if tag == "" {
+			b.WriteString("## default\n\n")
+		} else {
+			fmt.Fprintf(&b, "## %s\n\n", tag)
+		}
+		if d := descriptions[tag]; d != "" {
+			fmt.Fprintf(&b, "%s\n\n", d)
+		}
+
+		for _, op := range groups[tag] {
+			a.writeMarkdownOperation(&b, op.Path, op.Verb, op.Method)
+		}
+	}
+
+	if schemas := a.Components["schemas"]; len(schemas) > 0 {
+		b.WriteString("## Schemas\n\n")
+		for _, name := range sortedKeys(schemas) {
+			writeMarkdownSchema(&b, name, schemas[name])
+		}
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// markdownOrder returns the order in which to write the tags of groups.
+func (a API) markdownOrder(groups map[string][]Operation) []string {
+	var order []string
+	for _, t := range a.Tags {
+		if _, ok := groups[t.Name]; ok && !contains(order, t.Name) {
+			order = append(order, t.Name)
+		}
+	}
+	for _, tag := range sortedKeys(groups) {
+		if tag != "" && !contains(order, tag) {
+			order = append(order, tag)
+		}
+	}
+	if _, ok := groups[""]; ok {
+		order = append(order, "")
+	}
+
+	return order
+}
+
+// writeMarkdownOperation writes the section for a single operation.
+// References to responses and headers are resolved, those which cannot be are written as they are.
+func (a API) writeMarkdownOperation(b *bytes.Buffer, path, verb string, m Method) {
+	responses := make(map[string]Response, len(m.Responses))
+	for code, r := range m.Responses {
+		if resolved, err := a.ResolveResponse(r); err == nil {
+			r = resolved
+		}
+		responses[code] = r
+	}
+
+	fmt.Fprintf(b, "### `%s %s`\n\n", strings.ToUpper(verb), path)
+	if m.Deprecated {
+		b.WriteString("**Deprecated**\n\n")
+	}
+	if m.Summary != "" {
+		fmt.Fprintf(b, "%s\n\n", m.Summary)
+	}
+	if m.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", m.Description)
+	}
+
+	if params := a.EffectiveParameters(path, verb); len(params) > 0 {
+		b.WriteString("#### Parameters\n\n")
+		b.WriteString("| Name | In | Required | Type |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, p := range params {
+			required := "no"
+			if p.Required {
+				required = "yes"
+			}
+			fmt.Fprintf(b, "| %s | %s | %s | %s |\n", markdownCell(p.Name), p.In, required, markdownCell(typeName(p.Schema)))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(responses) > 0 {
+		b.WriteString("#### Responses\n\n")
+		b.WriteString("| Code | Description |\n")
+		b.WriteString("| --- | --- |\n")
+		for _, code := range sortedKeys(responses) {
+			fmt.Fprintf(b, "| %s | %s |\n", code, markdownCell(responses[code].Description))
+		}
+		b.WriteString("\n")
+	}
+
+	var headers []string
+	for _, code := range sortedKeys(responses) {
+		r := responses[code]
+		for _, name := range sortedKeys(r.Headers) {
+			h := r.Headers[name]
+			if h.Ref != "" {
+				if resolved, err := a.ResolveHeaderRef(h.Ref); err == nil {
+					h = resolved
+				}
+			}
+			required := "no"
+			if h.Required {
+				required = "yes"
+			}
+			headers = append(headers, fmt.Sprintf("| %s | %s | %s | %s | %s |\n", code, markdownCell(name), required, markdownCell(typeName(h.Schema)), markdownCell(h.Description)))
+		}
+	}
+	if len(headers) > 0 {
+		b.WriteString("#### Response headers\n\n")
+		b.WriteString("| Code | Name | Required | Type | Description |\n")
+		b.WriteString("| --- | --- | --- | --- | --- |\n")
+		b.WriteString(strings.Join(headers, ""))
+		b.WriteString("\n")
+	}
+
+	var links []string
+	for _, code := range sortedKeys(responses) {
+		r := responses[code]
+		for _, name := range sortedKeys(r.Links) {
+			l := r.Links[name]
+			op := l.OperationID
+			if op == "" {
+				op = l.OperationRef
+			}
+			links = append(links, fmt.Sprintf("| %s | %s | %s | %s |\n", code, markdownCell(name), markdownCell(op), markdownCell(l.Description)))
+		}
+	}
+	if len(links) > 0 {
+		b.WriteString("#### Links\n\n")
+		b.WriteString("| Code | Name | Operation | Description |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		b.WriteString(strings.Join(links, ""))
+		b.WriteString("\n")
+	}
+}
+
+// writeMarkdownSchema writes the section for a single schema.
+func writeMarkdownSchema(b *bytes.Buffer, name string, t Type) {
+	if title := strings.Join(strings.Fields(t.Title), " "); title != "" {
+		fmt.Fprintf(b, "### %s (`%s`)\n\n", title, name)
+	} else {
+		fmt.Fprintf(b, "### `%s`\n\n", name)
+	}
+	if t.Description != "" {
+		fmt.Fprintf(b, "%s\n\n", t.Description)
+	}
+
+	if len(t.Properties) > 0 {
+		b.WriteString("| Name | Type | Required | Description |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, prop := range sortedKeys(t.Properties) {
+			p := t.Properties[prop]
+			required := "no"
+			if contains(t.Required, prop) {
+				required = "yes"
+			}
+			description := p.Description
+			if description == "" {
+				description = p.Title
+			}
+			fmt.Fprintf(b, "| %s | %s | %s | %s |\n", markdownCell(prop), markdownCell(typeName(Schema{Type: p.Type, Ref: p.Ref, Items: p.Items})), required, markdownCell(description))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// markdownCell escapes text to fit within a single table cell.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Merge combines specs into a single API, taking the union of their Paths, components, Servers, and Tags.
+// Components include those with dedicated fields, such as SecuritySchemes.
+// Version, Info, ExternalDocs, and Security are those of the first spec which sets them.
+// Servers and Tags are deduplicated by URL and name, keeping the first.
+// It is an error for two specs to define the same operation, different fields shared by the operations of a path,
+// or different components of the same kind and name.
+// Identical components are permitted, as shared definitions are often copied between files.
+// Extensions, and OtherComponents, are those of the first spec which sets each.
+func Merge(specs ...API) (API, error) {
+	var out API
+	opOwner := make(map[string]int)
+	pathOwner := make(map[string]int)
+	componentOwner := make(map[string]int)
+
+	for i, a := range specs {
+		if out.Version == "" {
+			out.Version = a.Version
+		}
+		if reflect.DeepEqual(out.Info, Info{}) {
+			out.Info = a.Info
+		}
+		if out.ExternalDocs == nil {
+			out.ExternalDocs = a.ExternalDocs
+		}
+		if out.Security == nil {
+			out.Security = a.Security
+		}
+
+		for _, s := range a.Servers {
+			if !containsServer(out.Servers, s.URL) {
+				out.Servers = append(out.Servers, s)
+			}
+		}
+		for _, t := range a.Tags {
+			if !containsTag(out.Tags, t.Name) {
+				out.Tags = append(out.Tags, t)
+			}
+		}
+
+		for _, path := range a.SortedPaths() {
+			if out.Paths == nil {
+				out.Paths = make(map[string]PathItem)
+			}
+
+			// The fields shared by the operations on a path must agree
+			item := out.Paths[path]
+			if shared := sharedFields(a.Paths[path]); !isZero(shared) {
+				existing := sharedFields(item)
+				if !isZero(existing) && !reflect.DeepEqual(existing, shared) {
+					return out, fmt.Errorf("merge: %s: conflicting path items in specs %d and %d", path, pathOwner[path], i)
+				} else if isZero(existing) {
+					pathOwner[path] = i
+				}
+				shared.Methods = item.Methods
+				item = shared
+			}
+
+			for _, verb := range a.SortedVerbs(path) {
+				key := verb + " " + path
+				if j, ok := opOwner[key]; ok {
+					return out, fmt.Errorf("merge: %s: defined by specs %d and %d", key, j, i)
+				}
+				opOwner[key] = i
+
+				if item.Methods == nil {
+					item.Methods = make(map[string]Method)
+				}
+				item.Methods[verb] = a.Paths[path].Methods[verb]
+			}
+			out.Paths[path] = item
+		}
+
+		for _, kind := range sortedKeys(a.Components) {
+			for _, name := range sortedKeys(a.Components[kind]) {
+				t := a.Components[kind][name]
+				if out.Components == nil {
+					out.Components = make(map[string]map[string]Type)
+				}
+				if out.Components[kind] == nil {
+					out.Components[kind] = make(map[string]Type)
+				}
+
+				ref := componentRef(kind, name)
+				if existing, ok := out.Components[kind][name]; ok && !reflect.DeepEqual(existing, t) {
+					return out, fmt.Errorf("merge: %s: conflicting definitions in specs %d and %d", ref, componentOwner[ref], i)
+				} else if !ok {
+					componentOwner[ref] = i
+				}
+				out.Components[kind][name] = t
+			}
+		}
+
+		// Components with a dedicated field
+		fields, outFields := a.componentFields(), out.componentFields()
+		for _, kind := range sortedKeys(fields) {
+			src := reflect.ValueOf(fields[kind]).Elem()
+			dst := reflect.ValueOf(outFields[kind]).Elem()
+			for _, name := range sortedKeys(src.Interface()) {
+				k := reflect.ValueOf(name)
+				v := src.MapIndex(k)
+				if dst.IsNil() {
+					dst.Set(reflect.MakeMap(dst.Type()))
+				}
+
+				ref := componentRef(kind, name)
+				if existing := dst.MapIndex(k); existing.IsValid() && !reflect.DeepEqual(existing.Interface(), v.Interface()) {
+					return out, fmt.Errorf("merge: %s: conflicting definitions in specs %d and %d", ref, componentOwner[ref], i)
+				} else if !existing.IsValid() {
+					componentOwner[ref] = i
+				}
+				dst.SetMapIndex(k, v)
+			}
+		}
+
+		for k, v := range a.OtherComponents {
+			if out.OtherComponents == nil {
+				out.OtherComponents = make(map[string]json.RawMessage)
+			}
+			if _, ok := out.OtherComponents[k]; !ok {
+				out.OtherComponents[k] = v
+			}
+		}
+
+		for k, v := range a.Extensions {
+			if out.Extensions == nil {
+				out.Extensions = make(map[string]json.RawMessage)
+			}
+			if _, ok := out.Extensions[k]; !ok {
+				out.Extensions[k] = v
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// sharedFields returns the path item without its operations.
+func sharedFields(p PathItem) PathItem {
+	p.Methods = nil
+	return p
+}
+
+// containsServer reports whether servers includes one with the given URL.
+func containsServer(servers []Server, url string) bool {
+	for _, s := range servers {
+		if s.URL == url {
+			return true
+		}
+	}
+	return false
+}
+
+// containsTag reports whether tags includes one with the given name.
+func containsTag(tags []Tag, name string) bool {
+	for _, t := range tags {
+		if t.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMerge(t *testing.T) {
+	pets := `{"openapi": "3.0.3", "info": {"title": "Pets", "version": "1"},
+		"servers": [{"url": "/v1"}], "tags": [{"name": "pets"}], "x-a": 1,
+		"paths": {"/pets": {"summary": "All pets", "get": {"responses": {"200": {"description": "ok"}}}}},
+		"components": {"schemas": {"Pet": {"type": "object"}, "Id": {"type": "integer"}},
+			"headers": {"Rate": {"schema": {"type": "integer"}}}}}`
+
+	tests := []struct {
+		name string
+		spec string
+		err  string // Substring of the expected error, or empty if none
+	}{
+		{
+			name: "disjoint",
+			spec: `{"openapi": "3.1.0", "info": {"title": "Owners", "version": "2"},
+				"servers": [{"url": "/v1"}, {"url": "/v2"}], "tags": [{"name": "owners"}, {"name": "pets"}], "x-a": 2, "x-b": 2,
+				"paths": {"/pets": {"post": {"responses": {"201": {"description": "ok"}}}},
+					"/owners": {"get": {"responses": {"200": {"description": "ok"}}}}},
+				"components": {"schemas": {"Owner": {"type": "object"}, "Id": {"type": "integer"}},
+					"headers": {"Rate": {"schema": {"type": "integer"}}}, "links": {"Next": {"operationId": "a"}}}}`,
+		},
+		{
+			name: "same operation",
+			spec: `{"paths": {"/pets": {"get": {"responses": {"200": {"description": "ok"}}}}}}`,
+			err:  "merge: get /pets: defined by specs 0 and 1",
+		},
+		{
+			name: "conflicting schema",
+			spec: `{"components": {"schemas": {"Id": {"type": "string"}}}}`,
+			err:  "merge: #/components/schemas/Id: conflicting definitions in specs 0 and 1",
+		},
+		{
+			name: "conflicting header",
+			spec: `{"components": {"headers": {"Rate": {"schema": {"type": "string"}}}}}`,
+			err:  "merge: #/components/headers/Rate: conflicting definitions in specs 0 and 1",
+		},
+		{
+			name: "conflicting path item",
+			spec: `{"paths": {"/pets": {"summary": "Pets", "post": {"responses": {"201": {"description": "ok"}}}}}}`,
+			err:  "merge: /pets: conflicting path items in specs 0 and 1",
+		},
+	}
+
+	first, err := ParseString(pets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			second, err := ParseString(test.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			out, err := Merge(first, second)
+			switch {
+			case test.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.err != "" && err == nil:
+				t.Fatalf("expected error containing %q", test.err)
+			case test.err != "" && !strings.Contains(err.Error(), test.err):
+				t.Fatalf("error %q does not contain %q", err, test.err)
+			case test.err != "":
+				return
+			}
+
+			checks := []struct {
+				name      string
+				got, want interface{}
+			}{
+				{"version", out.Version, "3.0.3"},
+				{"title", out.Info.Title, "Pets"},
+				{"servers", out.Servers, []Server{{URL: "/v1"}, {URL: "/v2"}}},
+				{"tags", out.Tags, []Tag{{Name: "pets"}, {Name: "owners"}}},
+				{"paths", out.SortedPaths(), []string{"/owners", "/pets"}},
+				{"verbs", out.SortedVerbs("/pets"), []string{"get", "post"}},
+				{"schemas", sortedKeys(out.Components["schemas"]), []string{"Id", "Owner", "Pet"}},
+				{"headers", sortedKeys(out.Headers), []string{"Rate"}},
+				{"links", sortedKeys(out.Links), []string{"Next"}},
+				{"extension of first", string(out.Extensions["x-a"]), "1"},
+				{"extension of second", string(out.Extensions["x-b"]), "2"},
+			}
+			for _, c := range checks {
+				if !reflect.DeepEqual(c.got, c.want) {
+					t.Errorf("%s: expected %#v, got %#v", c.name, c.want, c.got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// quotedTemplatePattern matches a templated segment of a path quoted by regexp.QuoteMeta, such as `\{id\}`.
+var quotedTemplatePattern = regexp.MustCompile(`\\\{[^{}]*\\\}`)
+
+// MockHandler returns a handler which serves every operation of the API with a canned response, such as for developing against an API which does not yet exist.
+// Each operation responds with its lowest 2xx status code, or 200 if it declares none.
+// The body is the example of the response's content, preferring "application/json", or else the default of its schema, or else a value synthesized from its schema as by ExampleFor.
+// Paths without templated segments, such as "/users/me", take precedence over those with, such as "/users/{id}".
+// Unknown paths produce 404 Not Found, and unknown verbs 405 Method Not Allowed.
+func (a API) MockHandler() http.Handler {
+	matchers := a.pathMatchers()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		path, ok := matchPath(matchers, r.URL.Path)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+
+		m, ok := a.Paths[path].Methods[strings.ToLower(r.Method)]
+		if !ok {
+			var allow []string
+			for _, verb := range a.SortedVerbs(path) {
+				if isVerb(verb) {
+					allow = append(allow, strings.ToUpper(verb))
+				}
+			}
+			w.Header().Set("Allow", strings.Join(allow, ", "))
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		a.mockResponse(w, m)
+	})
+
+	return mux
+}
+
+// pathMatcher matches request paths to a path of the API.
+type pathMatcher struct {
+	path    string
+	pattern *regexp.Regexp
+	params  int // Number of templated segments
+}
+
+// pathMatchers returns a pathMatcher for each path of the API.
+// Paths without templated segments, such as "/users/me", precede those with, such as "/users/{id}".
+func (a API) pathMatchers() []pathMatcher {
+	var matchers []pathMatcher
+	for _, path := range a.SortedPaths() {
+		// Templated segments, whose braces QuoteMeta escapes, match any single segment
+		expr := quotedTemplatePattern.ReplaceAllString("^"+regexp.QuoteMeta(path)+"$", "[^/]+")
+		matchers = append(matchers, pathMatcher{path, regexp.MustCompile(expr), len(templatePattern.FindAllString(path, -1))})
+	}
+	sort.SliceStable(matchers, func(i, j int) bool { return matchers[i].params < matchers[j].params })
+
+	return matchers
+}
+
+// matchPath returns the path of the API for the first of matchers which matches the request path, such as "/pets/{id}" for "/pets/3".
+func matchPath(matchers []pathMatcher, request string) (string, bool) {
+	for _, pm := range matchers {
+		if pm.pattern.MatchString(request) {
+			return pm.path, true
+		}
+	}
+	return "", false
+}
+
+// mockResponse writes the canned response of m.
+func (a API) mockResponse(w http.ResponseWriter, m Method) {
+	status, code := http.StatusOK, ""
+	for _, c := range sortedKeys(m.Responses) {
+		if successPattern.MatchString(c) {
+			code = c
+			if n, err := strconv.Atoi(strings.Replace(c, "XX", "00", 1)); err == nil {
+				status = n
+			}
+			break
+		}
+	}
+
+	r, _ := a.ResolveResponse(m.Responses[code])
+
+	mediaType := ""
+	if _, ok := r.Content["application/json"]; ok {
+		mediaType = "application/json"
+	} else if types := sortedKeys(r.Content); len(types) > 0 {
+		mediaType = types[0]
+	}
+	if mediaType == "" {
+		w.WriteHeader(status)
+		return
+	}
+
+	mt := r.Content[mediaType]
+	body := mt.Example
+	if body == nil {
+		for _, name := range sortedKeys(mt.Examples) {
+			e := mt.Examples[name]
+			if e.Ref != "" {
+				e, _ = a.ResolveExampleRef(e.Ref)
+			}
+			if v := e.Value; v != nil {
+				body = v
+				break
+			}
+		}
+	}
+	if body == nil {
+		body = mt.Schema.Default
+	}
+	if body == nil {
+		v, err := a.example(mt.Schema.property(), nil)
+		if err == nil {
+			body, err = marshal(v)
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+// typeProperty converts a Type to the equivalent Property, keeping its reference.
+func typeProperty(t Type) Property {
+	p := t.property()
+	p.Ref = t.Ref
+	return p
+}
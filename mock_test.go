@@ -0,0 +1,63 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMockHandler(t *testing.T) {
+	api, err := ParseString(`{"paths": {
+		"/pets": {
+			"get": {"responses": {"200": {"description": "ok", "content": {"application/json": {"schema": {"type": "array", "items": {"type": "string"}, "default": ["rex"]}}}}}},
+			"post": {"responses": {"2000": {"description": "bad"}, "201": {"description": "ok",
+				"content": {"application/json": {"schema": {"type": "object", "required": ["name"], "properties": {"name": {"type": "string", "example": "rex"}}}}}}}}
+		},
+		"/pets/{id}": {
+			"get": {"responses": {"404": {"description": "none"}, "2XX": {"description": "ok",
+				"content": {"text/plain": {"example": "a"}, "application/json": {"example": {"id": 1}}}}}},
+			"delete": {"responses": {"204": {"description": "ok"}}}
+		},
+		"/pets/me": {"get": {"responses": {"200": {"description": "ok", "content": {"application/json": {"examples": {"me": {"value": {"id": 0}}}}}}}}},
+		"/owners": {"get": {"responses": {"default": {"description": "ok"}}}}
+	}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := api.MockHandler()
+
+	tests := []struct {
+		verb, path string
+		status     int
+		body       string
+		header     string // Expected Content-Type, or Allow for 405 Method Not Allowed
+	}{
+		{"GET", "/pets", 200, `["rex"]`, "application/json"},
+		{"POST", "/pets", 201, `{"name":"rex"}`, "application/json"},
+		{"GET", "/pets/7", 200, `{"id": 1}`, "application/json"},
+		{"GET", "/pets/me", 200, `{"id": 0}`, "application/json"},
+		{"DELETE", "/pets/7", 204, "", ""},
+		{"GET", "/owners", 200, "", ""},
+		{"PUT", "/pets/7", 405, "Method Not Allowed\n", "GET, DELETE"},
+		{"GET", "/stores", 404, "404 page not found\n", "text/plain; charset=utf-8"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.verb+" "+test.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(test.verb, test.path, nil))
+
+			header := w.Header().Get("Content-Type")
+			if w.Code == http.StatusMethodNotAllowed {
+				header = w.Header().Get("Allow")
+			}
+			if w.Code != test.status || strings.TrimSpace(w.Body.String()) != strings.TrimSpace(test.body) || header != test.header {
+				t.Errorf("expected %d %q %q, got %d %q %q", test.status, test.body, test.header, w.Code, w.Body.String(), header)
+			}
+		})
+	}
+}
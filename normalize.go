@@ -0,0 +1,202 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// NormalizeTo30 returns a copy of the API with the conventions of OpenAPI 3.1 translated to those of OpenAPI 3.0:
+//   - A "null" type becomes nullable, as ["string", "null"] becomes "string" with nullable set
+//   - A numeric exclusiveMinimum or exclusiveMaximum becomes the minimum or maximum with a boolean exclusiveMinimum or exclusiveMaximum
+//   - A version of 3.1 becomes 3.0.3
+//
+// Other features of OpenAPI 3.1, and of JSON Schema, are left as-is, such as a type of several non-null types, which OpenAPI 3.0 cannot express.
+func (a API) NormalizeTo30() API {
+	out := rewrite(reflect.ValueOf(a), func(v reflect.Value) {
+		if !v.CanAddr() {
+			return
+		}
+		switch n := v.Addr().Interface().(type) {
+		case *Type:
+			nullableTo30(&n.Is, &n.Nullable)
+			n.Constraints.exclusiveTo30()
+		case *Property:
+			nullableTo30(&n.Type, &n.Nullable)
+			n.Constraints.exclusiveTo30()
+		case *Schema:
+			nullableTo30(&n.Type, &n.Nullable)
+			n.Constraints.exclusiveTo30()
+		}
+	}).Interface().(API)
+
+	if strings.HasPrefix(out.Version, "3.1") {
+		out.Version = "3.0.3"
+	}
+	return out
+}
+
+// NormalizeTo31 returns a copy of the API with the conventions of OpenAPI 3.0 translated to those of OpenAPI 3.1:
+//   - Nullable becomes a "null" type, as "string" with nullable set becomes ["string", "null"]
+//   - A boolean exclusiveMinimum or exclusiveMaximum becomes the numeric bound, in place of the minimum or maximum
+//   - A version of 3.0 becomes 3.1.0
+//
+// Other differences between OpenAPI 3.0 and 3.1 are left as-is.
+func (a API) NormalizeTo31() API {
+	out := rewrite(reflect.ValueOf(a), func(v reflect.Value) {
+		if !v.CanAddr() {
+			return
+		}
+		switch n := v.Addr().Interface().(type) {
+		case *Type:
+			nullableTo31(&n.Is, &n.Nullable)
+			n.Constraints.exclusiveTo31()
+		case *Property:
+			nullableTo31(&n.Type, &n.Nullable)
+			n.Constraints.exclusiveTo31()
+		case *Schema:
+			nullableTo31(&n.Type, &n.Nullable)
+			n.Constraints.exclusiveTo31()
+		}
+	}).Interface().(API)
+
+	if strings.HasPrefix(out.Version, "3.0") {
+		out.Version = "3.1.0"
+	}
+	return out
+}
+
+// nullableTo30 replaces a "null" type with nullable.
+// A type of only "null" is left as-is, as OpenAPI 3.0 has no equivalent.
+func nullableTo30(typ *Types, nullable *bool) {
+	if !typ.IsNullable() || len(*typ) < 2 {
+		return
+	}
+
+	var types Types
+	for _, t := range *typ {
+		if t != "null" {
+			types = append(types, t)
+		}
+	}
+	*typ, *nullable = types, true
+}
+
+// nullableTo31 replaces nullable with a "null" type.
+// Without a type, any value, including null, is permitted in OpenAPI 3.1, so nullable is dropped.
+func nullableTo31(typ *Types, nullable *bool) {
+	if !*nullable {
+		return
+	}
+
+	if len(*typ) > 0 && !typ.IsNullable() {
+		*typ = append(append(Types{}, *typ...), "null")
+	}
+	*nullable = false
+}
+
+// exclusiveTo30 replaces numeric exclusive bounds with the minimum or maximum, marked exclusive.
+// If the inclusive bound is already the stricter, the exclusive bound is dropped.
+func (c *Constraints) exclusiveTo30() {
+	if e := c.ExclusiveMinimum; e != nil && e.Value != nil {
+		if c.Minimum == nil || *e.Value >= *c.Minimum {
+			c.Minimum, c.ExclusiveMinimum = e.Value, &Exclusive{Bool: true}
+		} else {
+			c.ExclusiveMinimum = nil
+		}
+	}
+	if e := c.ExclusiveMaximum; e != nil && e.Value != nil {
+		if c.Maximum == nil || *e.Value <= *c.Maximum {
+			c.Maximum, c.ExclusiveMaximum = e.Value, &Exclusive{Bool: true}
+		} else {
+			c.ExclusiveMaximum = nil
+		}
+	}
+}
+
+// exclusiveTo31 replaces a minimum or maximum marked exclusive with the numeric exclusive bound.
+// A boolean exclusive bound without a minimum or maximum, or which is false, is dropped.
+func (c *Constraints) exclusiveTo31() {
+	if e := c.ExclusiveMinimum; e != nil && e.Value == nil {
+		c.ExclusiveMinimum = nil
+		if e.Bool && c.Minimum != nil {
+			c.Minimum, c.ExclusiveMinimum = nil, &Exclusive{Value: c.Minimum}
+		}
+	}
+	if e := c.ExclusiveMaximum; e != nil && e.Value == nil {
+		c.ExclusiveMaximum = nil
+		if e.Bool && c.Maximum != nil {
+			c.Maximum, c.ExclusiveMaximum = nil, &Exclusive{Value: c.Maximum}
+		}
+	}
+}
+
+// Canonicalize returns a copy of the API in a canonical form, so that specifications which differ only in ordering serialize identically.
+// Lists without meaningful order are sorted and deduplicated: the required properties of schemas, the tags of operations, and the tags of the API, by name.
+// Raw JSON values, such as examples, defaults, and extensions, are compacted with their object members sorted.
+// Lists whose order is meaningful, such as parameters, servers, and enumerated values, are left as-is.
+// Maps need no ordering, as they are serialized with their keys sorted.
+func (a API) Canonicalize() API {
+	out := rewrite(reflect.ValueOf(a), func(v reflect.Value) {
+		if !v.CanAddr() {
+			return
+		}
+		switch n := v.Addr().Interface().(type) {
+		case *Type:
+			n.Required = sortedSet(n.Required)
+		case *Property:
+			n.Required = sortedSet(n.Required)
+		case *Schema:
+			n.Required = sortedSet(n.Required)
+		case *Method:
+			n.Tags = sortedSet(n.Tags)
+		case *json.RawMessage:
+			*n = canonicalJSON(*n)
+		}
+	}).Interface().(API)
+
+	sort.SliceStable(out.Tags, func(i, j int) bool { return out.Tags[i].Name < out.Tags[j].Name })
+	return out
+}
+
+// sortedSet returns list sorted, without duplicates.
+func sortedSet(list []string) []string {
+	if list == nil {
+		return nil
+	}
+
+	out := make([]string, 0, len(list))
+	for _, s := range list {
+		if !contains(out, s) {
+			out = append(out, s)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// canonicalJSON returns the JSON text b compacted, with object members sorted by key.
+// Invalid JSON is returned as-is.
+func canonicalJSON(b json.RawMessage) json.RawMessage {
+	if b == nil {
+		return nil
+	}
+
+	var v interface{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return b
+	}
+
+	out, err := marshal(v)
+	if err != nil {
+		return b
+	}
+	return out
+}
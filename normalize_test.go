@@ -0,0 +1,118 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// schemaJSON returns the JSON of the schema component A of the API, decoded for comparison.
+func schemaJSON(t *testing.T, a API) interface{} {
+	t.Helper()
+	b, err := marshal(a.Components["schemas"]["A"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name string
+		v31  string // Schema A in the conventions of OpenAPI 3.1
+		v30  string // Schema A in the conventions of OpenAPI 3.0
+		to31 string // Schema A of v30 converted back by NormalizeTo31, if not v31
+	}{
+		{name: "nullable", v31: `{"type": ["string", "null"]}`, v30: `{"type": "string", "nullable": true}`},
+		{name: "not nullable", v31: `{"type": "string"}`, v30: `{"type": "string"}`},
+		{name: "several types", v31: `{"type": ["string", "integer"]}`, v30: `{"type": ["string", "integer"]}`},
+		{name: "only null", v31: `{"type": "null"}`, v30: `{"type": "null"}`},
+		{name: "nested", v31: `{"type": "object", "properties": {"a": {"type": "array", "items": {"type": ["integer", "null"]}}}}`,
+			v30: `{"type": "object", "properties": {"a": {"type": "array", "items": {"type": "integer", "nullable": true}}}}`},
+		{name: "exclusive minimum", v31: `{"type": "number", "exclusiveMinimum": 5}`, v30: `{"type": "number", "minimum": 5, "exclusiveMinimum": true}`},
+		{name: "exclusive maximum", v31: `{"type": "number", "exclusiveMaximum": 5}`, v30: `{"type": "number", "maximum": 5, "exclusiveMaximum": true}`},
+		{name: "stricter minimum", v31: `{"type": "number", "minimum": 10, "exclusiveMinimum": 5}`, v30: `{"type": "number", "minimum": 10}`,
+			to31: `{"type": "number", "minimum": 10}`},
+		{name: "inclusive bounds", v31: `{"type": "number", "minimum": 1, "maximum": 2}`, v30: `{"type": "number", "minimum": 1, "maximum": 2}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parse := func(version, schema string) API {
+				a, err := ParseString(`{"openapi": "` + version + `", "components": {"schemas": {"A": ` + schema + `}}}`)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return a
+			}
+			v31, v30 := parse("3.1.0", test.v31), parse("3.0.3", test.v30)
+			to31 := v31
+			if test.to31 != "" {
+				to31 = parse("3.1.0", test.to31)
+			}
+
+			if got := v31.NormalizeTo30(); !reflect.DeepEqual(schemaJSON(t, got), schemaJSON(t, v30)) || got.Version != "3.0.3" {
+				t.Errorf("NormalizeTo30: expected %s, got %v at %s", test.v30, schemaJSON(t, got), got.Version)
+			}
+			if got := v30.NormalizeTo31(); !reflect.DeepEqual(schemaJSON(t, got), schemaJSON(t, to31)) || got.Version != "3.1.0" {
+				t.Errorf("NormalizeTo31: expected %v, got %v at %s", schemaJSON(t, to31), schemaJSON(t, got), got.Version)
+			}
+		})
+	}
+
+	// The API itself is not modified
+	a, err := ParseString(`{"openapi": "3.1.0", "components": {"schemas": {"A": {"type": ["string", "null"]}}}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.NormalizeTo30()
+	if got := a.Components["schemas"]["A"].Is; !reflect.DeepEqual(got, Types{"string", "null"}) {
+		t.Errorf("NormalizeTo30 modified the API: type %v", got)
+	}
+}
+
+func TestCanonicalize(t *testing.T) {
+	a := `{"openapi": "3.0.3", "tags": [{"name": "b"}, {"name": "a"}], "x-e": {"b": 1, "a": [2, 1]},
+		"paths": {"/p": {"get": {"tags": ["b", "a", "b"],
+			"parameters": [{"name": "y", "in": "query"}, {"name": "x", "in": "query"}],
+			"responses": {"200": {"description": "ok", "content": {"application/json": {"example": {"b": 1,  "a": {"d": 1, "c": 2}}}}}}}}},
+		"components": {"schemas": {"A": {"type": "object", "required": ["b", "a", "a"], "enum": [{"b": 1}, {"a": 2}],
+			"properties": {"a": {"type": "string"}, "b": {"type": "object", "required": ["d", "c"], "default": {"y": 1, "x": 2}}}}}}}`
+	b := `{"openapi": "3.0.3", "tags": [{"name": "a"}, {"name": "b"}], "x-e": {"a": [2, 1], "b": 1},
+		"paths": {"/p": {"get": {"tags": ["a", "b"],
+			"parameters": [{"name": "y", "in": "query"}, {"name": "x", "in": "query"}],
+			"responses": {"200": {"description": "ok", "content": {"application/json": {"example": {"a": {"c": 2, "d": 1}, "b": 1}}}}}}}},
+		"components": {"schemas": {"A": {"type": "object", "required": ["a", "b"], "enum": [{"b": 1}, {"a": 2}],
+			"properties": {"b": {"type": "object", "required": ["c", "d"], "default": {"x": 2, "y": 1}}, "a": {"type": "string"}}}}}}`
+
+	var out []string
+	for _, spec := range []string{a, b} {
+		api, err := ParseString(spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m, err := api.Canonicalize().Marshal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		out = append(out, string(m))
+	}
+	if out[0] != out[1] {
+		t.Errorf("canonical forms differ:\n%s\n%s", out[0], out[1])
+	}
+
+	// Lists whose order is meaningful are kept
+	for _, want := range []string{`"parameters":[{"name":"y","in":"query"},{"name":"x","in":"query"}]`, `"enum":[{"b":1},{"a":2}]`, `"x-e":{"a":[2,1],"b":1}`} {
+		if !strings.Contains(out[0], want) {
+			t.Errorf("canonical form lacks %s:\n%s", want, out[0])
+		}
+	}
+}
@@ -6,28 +6,86 @@
 package openapi
 
 import (
-	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
+	"os"
+	"strings"
 )
 
 // API represents an OpenAPI specification instance.
 // This is the top-level type.
 type API struct {
-	Version    string                       `json:"openapi"`    // OpenAPI semantic version
-	Info       Info                         `json:"info"`       // Meta-information about the API
-	Servers    []Server                     `json:"servers"`    // Servers the API may be accessible from
-	Paths      map[string]map[string]Method `json:"paths"`      // Paths the API serves for callers
-	Components map[string]map[string]Type   `json:"components"` // Types, etc. present within the API paths
+	Version    string                     `json:"openapi"`              // OpenAPI semantic version
+	Info       Info                       `json:"info"`                 // Meta-information about the API
+	Servers    []Server                   `json:"servers,omitempty"`    // Servers the API may be accessible from
+	Paths      map[string]PathItem        `json:"paths,omitempty"`      // Paths the API serves for callers
+	Components map[string]map[string]Type `json:"components,omitempty"` // Types, etc. present within the API paths
+	Tags       []Tag                      `json:"tags,omitempty"`       // Descriptions of the tags used to classify methods
+
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"` // Additional documentation for the API
+
+	// Security lists the alternative security requirements for calling the API, keyed by security scheme name.
+	// A nil Security is absent from the specification, whereas an empty Security requires no authentication.
+	Security []map[string][]string `json:"security,omitempty"`
+
+	// SecuritySchemes holds the "securitySchemes" within the specification's components, by name.
+	// Such components are not present in Components.
+	SecuritySchemes map[string]SecurityScheme `json:"-"`
+
+	// Parameters, Responses, RequestBodies, Examples, Headers, and Links hold the "parameters", "responses", "requestBodies", "examples", "headers", and "links"
+	// within the specification's components, by name.
+	// Such components are not present in Components.
+	Parameters    map[string]Parameter   `json:"-"`
+	Responses     map[string]Response    `json:"-"`
+	RequestBodies map[string]RequestBody `json:"-"`
+	Examples      map[string]Example     `json:"-"`
+	Headers       map[string]Header      `json:"-"`
+	Links         map[string]Link        `json:"-"`
+
+	// OtherComponents holds the members of the specification's components which are not otherwise modeled, such as "callbacks", and extensions, as-is.
+	// Components holds only "schemas".
+	OtherComponents map[string]json.RawMessage `json:"-"`
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
 }
 
 // Type is a schema super type definition
 type Type struct {
+	Title       string `json:"title,omitempty"`       // Short label for the type
+	Description string `json:"description,omitempty"` // What the type represents
+
 	Required []string `json:"required,omitempty"` // List of required, dependant, entries
-	Is       string   `json:"type"`               // A value such as "object"
+	Is       Types    `json:"type,omitempty"`     // A value such as "object"
+	Ref      string   `json:"$ref,omitempty"`     // Reference to another Type, in place of a definition
+	Nullable bool     `json:"nullable,omitempty"` // May the value be null? OpenAPI 3.0 form, see Types for OpenAPI 3.1
+	Format   string   `json:"format,omitempty"`   // Refines Is, such as "int64" or "date-time"
+	Items    *Schema  `json:"items,omitempty"`    // Items expected, if an array
+	Enums    Enum     `json:"enum,omitempty"`     // Permitted values, if restricted
+
+	Deprecated bool `json:"deprecated,omitempty"`
+	ReadOnly   bool `json:"readOnly,omitempty"`  // Only sent in responses
+	WriteOnly  bool `json:"writeOnly,omitempty"` // Only sent in requests
+
+	Default json.RawMessage `json:"default,omitempty"` // Value assumed if none is provided
+	Example json.RawMessage `json:"example,omitempty"` // Example of a value
+	Const   json.RawMessage `json:"const,omitempty"`   // Only value permitted, OpenAPI 3.1
+	XML     *XML            `json:"xml,omitempty"`     // Representation as XML
 
 	// Properties has a structure similar to: `["SomeId"]{type, items}`
-	Properties map[string]Property `json:"properties"`
+	Properties map[string]Property `json:"properties,omitempty"`
+
+	Constraints
+	Composition
+	Discriminator *Discriminator `json:"discriminator,omitempty"` // Selects among OneOf or AnyOf, if polymorphic
+
+	// AdditionalProperties, if not nil, governs properties not present in Properties.
+	AdditionalProperties *AdditionalProperties `json:"additionalProperties,omitempty"`
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
 	/* Structure:
 	"properties" {
 		architectures
@@ -53,102 +111,448 @@ type Type struct {
 
 // Property is an entry in a map `["component"]{"properties"}` for a Type.Properties.
 type Property struct {
-	Type     string `json:"type,omitempty"`
-	Ref      string `json:"$ref,omitempty"`
-	Items    Schema `json:"items,omitempty"`
-	Format   string `json:"format,omitempty"`
-	Nullable bool   `json:"nullable,omitempty"`
+	Title       string `json:"title,omitempty"`       // Short label for the property
+	Description string `json:"description,omitempty"` // What the property represents
+
+	Type       Types   `json:"type,omitempty"`
+	Ref        string  `json:"$ref,omitempty"`
+	Items      *Schema `json:"items,omitempty"`
+	Format     string  `json:"format,omitempty"`
+	Nullable   bool    `json:"nullable,omitempty"`
+	Deprecated bool    `json:"deprecated,omitempty"`
+	ReadOnly   bool    `json:"readOnly,omitempty"`  // Only sent in responses
+	WriteOnly  bool    `json:"writeOnly,omitempty"` // Only sent in requests
 
-	Enums []string `json:"enum,omitempty"`
+	Enums Enum `json:"enum,omitempty"`
+
+	Default json.RawMessage `json:"default,omitempty"` // Value assumed if none is provided
+	Example json.RawMessage `json:"example,omitempty"` // Example of a value
+	Const   json.RawMessage `json:"const,omitempty"`   // Only value permitted, OpenAPI 3.1
+	XML     *XML            `json:"xml,omitempty"`     // Representation as XML
+
+	// Required and Properties describe an inline object, such as one inlined by API.Dereference.
+	Required   []string            `json:"required,omitempty"`
+	Properties map[string]Property `json:"properties,omitempty"`
+
+	Constraints
+	Composition
+	Discriminator *Discriminator `json:"discriminator,omitempty"` // Selects among OneOf or AnyOf, if polymorphic
+
+	// AdditionalProperties, if not nil, governs properties not present in Properties.
+	AdditionalProperties *AdditionalProperties `json:"additionalProperties,omitempty"`
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
 }
 
 // Schema represents the scheme for a given item or object.
 type Schema struct {
+	Title       string `json:"title,omitempty"`       // Short label for the scheme
+	Description string `json:"description,omitempty"` // What the scheme represents
+
 	// Enums is the enumerated values possible in the scheme, if any.
-	Enums []string `json:"enum,omitempty"`
+	Enums Enum `json:"enum,omitempty"`
 
-	// Items, if empty, indicates the scheme is not that of an array.
-	Items Item `json:"items,omitempty"` // Items expected in an array(?)
+	// Items, if nil, indicates the scheme is not that of an array.
+	// Items may itself be an array, as in an array of arrays.
+	Items *Schema `json:"items,omitempty"` // Items expected in an array
 
 	// Type, if empty, is not an array.
-	Type string `json:"type,omitempty"` // Type expected for input
+	Type     Types  `json:"type,omitempty"`     // Type expected for input
+	Format   string `json:"format,omitempty"`   // Refines Type, such as "int64" or "date-time"
+	Nullable bool   `json:"nullable,omitempty"` // May the value be null?
 
 	// Ref's value, if omitted, is probably in Property.Items["$ref"].
 	Ref string `json:"$ref,omitempty"` // Reference path
 
-	// Default is the default value of the scheme.
-	Default string `json:"default,omitempty"`
+	// Default is the default value of the scheme, of any JSON type.
+	Default json.RawMessage `json:"default,omitempty"`
+
+	Const json.RawMessage `json:"const,omitempty"` // Only value permitted, OpenAPI 3.1
+	XML   *XML            `json:"xml,omitempty"`   // Representation as XML
+
+	// Required and Properties describe the scheme if it is an inline object.
+	Required   []string            `json:"required,omitempty"`
+	Properties map[string]Property `json:"properties,omitempty"`
+
+	Constraints
+	Composition
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
+}
+
+// Constraints restrict the values permitted by a Type, Schema, or Property.
+// Nil fields are absent from the specification, as opposed to zero.
+type Constraints struct {
+	Minimum          *float64   `json:"minimum,omitempty"`
+	Maximum          *float64   `json:"maximum,omitempty"`
+	ExclusiveMinimum *Exclusive `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *Exclusive `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64   `json:"multipleOf,omitempty"`
+	MinLength        *int       `json:"minLength,omitempty"`
+	MaxLength        *int       `json:"maxLength,omitempty"`
+	MinItems         *int       `json:"minItems,omitempty"`
+	MaxItems         *int       `json:"maxItems,omitempty"`
+	UniqueItems      bool       `json:"uniqueItems,omitempty"` // Must the items of an array differ?
+	MinProperties    *int       `json:"minProperties,omitempty"`
+	MaxProperties    *int       `json:"maxProperties,omitempty"`
+	Pattern          string     `json:"pattern,omitempty"` // Regular expression a string must match
 }
 
-// Item represents an item in a set.
-type Item struct {
-	// Enums is the enumerated values possible in the item, if any.
-	Enums []string `json:"enum,omitempty"`
+// XML describes how a schema is represented as XML, such as the name of its element.
+type XML struct {
+	Name      string `json:"name,omitempty"`      // Name of the element or attribute, if not that of the property
+	Namespace string `json:"namespace,omitempty"` // URI of the namespace
+	Prefix    string `json:"prefix,omitempty"`    // Prefix of the name
+	Attribute bool   `json:"attribute,omitempty"` // Is the property an attribute, rather than an element?
+	Wrapped   bool   `json:"wrapped,omitempty"`   // Are the items of an array wrapped in an element of their own?
+}
+
+// Composition combines the Types a Type, Schema, or Property must match.
+// Each Type is often only a reference.
+type Composition struct {
+	AllOf []Type `json:"allOf,omitempty"` // Must match all of
+	OneOf []Type `json:"oneOf,omitempty"` // Must match exactly one of
+	AnyOf []Type `json:"anyOf,omitempty"` // Must match at least one of
+	Not   *Type  `json:"not,omitempty"`   // Must not match
+}
 
-	// Type is the type of the item, if any.
-	Type string `json:"type,omitempty"`
+// Discriminator names the property whose value determines which of a polymorphic schema's Types a value matches.
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"` // References or schema names by property value
+}
+
+// AdditionalProperties is the value of additionalProperties, which is either a boolean or a schema.
+// An object with a schema for its additional properties is a map of that schema, such as `map[string]T` in Go.
+type AdditionalProperties struct {
+	Allowed bool `json:"-"` // Are additional properties permitted? Always true if Property is not nil
+
+	// Property, if not nil, is the schema of each additional property.
+	*Property
+}
 
-	// Ref is the reference identifier of the item, if any.
-	Ref string `json:"$ref,omitempty"`
+// Exclusive is the value of exclusiveMinimum or exclusiveMaximum.
+// OpenAPI 3.0 uses a boolean, which makes minimum or maximum exclusive, whereas OpenAPI 3.1 uses the exclusive bound itself.
+type Exclusive struct {
+	Bool  bool     // OpenAPI 3.0 form, used if Value is nil
+	Value *float64 // OpenAPI 3.1 form
 }
 
+// Types is the type of a schema, such as "string".
+// OpenAPI 3.0 permits a single type, whereas OpenAPI 3.1 permits several, such as ["string", "null"] for a nullable string.
+type Types []string
+
+// Enum is the enumerated values of a schema, each of any JSON type, such as `"red"`, `1`, or `true`.
+type Enum []json.RawMessage
+
 // Info stores meta-information about the API.
 type Info struct {
-	Title   string `json:"title"`
-	Version string `json:"version"`
+	Title          string   `json:"title"`
+	Version        string   `json:"version"`
+	Description    string   `json:"description,omitempty"`
+	TermsOfService string   `json:"termsOfService,omitempty"` // URL of the terms of service
+	Contact        *Contact `json:"contact,omitempty"`
+	License        *License `json:"license,omitempty"`
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
+}
+
+// Contact is the contact information for the API.
+type Contact struct {
+	Name  string `json:"name,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// License is the license the API is provided under.
+type License struct {
+	Name       string `json:"name"`
+	URL        string `json:"url,omitempty"`
+	Identifier string `json:"identifier,omitempty"` // SPDX license expression, since OpenAPI 3.1
+}
+
+// Tag describes a tag used in Method.Tags.
+type Tag struct {
+	Name         string        `json:"name"`
+	Description  string        `json:"description,omitempty"`
+	ExternalDocs *ExternalDocs `json:"externalDocs,omitempty"`
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
+}
+
+// ExternalDocs refers to documentation hosted outside of the specification.
+type ExternalDocs struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+}
+
+// SecurityScheme describes a means of authenticating to the API.
+type SecurityScheme struct {
+	Type             string      `json:"type"`                       // One of "apiKey", "http", "oauth2", or "openIdConnect"
+	Description      string      `json:"description,omitempty"`      // What is the scheme?
+	Name             string      `json:"name,omitempty"`             // Name of the header, query, or cookie parameter for an "apiKey"
+	In               string      `json:"in,omitempty"`               // Where an "apiKey" occurs in the HTTP call
+	Scheme           string      `json:"scheme,omitempty"`           // HTTP authorization scheme for "http" — ex. "bearer"
+	BearerFormat     string      `json:"bearerFormat,omitempty"`     // Hint as to the format of a bearer token — ex. "JWT"
+	Flows            *OAuthFlows `json:"flows,omitempty"`            // Flows supported by "oauth2"
+	OpenIDConnectURL string      `json:"openIdConnectUrl,omitempty"` // Discovery URL for "openIdConnect"
+}
+
+// OAuthFlows holds the OAuth 2.0 flows supported by a SecurityScheme.
+type OAuthFlows struct {
+	Implicit          *OAuthFlow `json:"implicit,omitempty"`
+	Password          *OAuthFlow `json:"password,omitempty"`
+	ClientCredentials *OAuthFlow `json:"clientCredentials,omitempty"`
+	AuthorizationCode *OAuthFlow `json:"authorizationCode,omitempty"`
+}
+
+// OAuthFlow describes an OAuth 2.0 flow.
+type OAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty"`
+	RefreshURL       string            `json:"refreshUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes"` // Descriptions of the available scopes, by name
 }
 
 // Server URL the API is called from.
 type Server struct {
-	URL string `json:"url"`
+	URL         string                    `json:"url"`                   // May contain variables in braces — ex. "https://{region}.example.com"
+	Description string                    `json:"description,omitempty"` // What is the server?
+	Variables   map[string]ServerVariable `json:"variables,omitempty"`   // Substitutions for the variables in URL
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
+}
+
+// ServerVariable describes the values a variable in a Server URL may take.
+type ServerVariable struct {
+	Enums       []string `json:"enum,omitempty"` // Permitted values, if restricted
+	Default     string   `json:"default"`        // Value used if none is provided
+	Description string   `json:"description,omitempty"`
 }
 
 // Method describes the calling information for an API Path.
 type Method struct {
-	Tags        []string                       `json:"tags"`        // Tags (if any) for classifying the method
-	Summary     string                         `json:"summary"`     // What does the method call provide/do?
-	Description string                         `json:"description"` // ↑ ⊻ with Summary
-	OperationID string                         `json:"operationId"` // Identifier for what is done
-	Parameters  []Parameter                    `json:"parameters"`  // Parameters that the method may be called with
-	Responses   map[string]Response            `json:"responses"`   // Expected responses for call in the form of `["HTTP code"]description`
-	RequestBody `json:"requestBody,omitempty"` // Body of the Response, if any
+	Tags         []string                       `json:"tags,omitempty"`         // Tags (if any) for classifying the method
+	Summary      string                         `json:"summary,omitempty"`      // What does the method call provide/do?
+	Description  string                         `json:"description,omitempty"`  // ↑ ⊻ with Summary
+	OperationID  string                         `json:"operationId,omitempty"`  // Identifier for what is done
+	ExternalDocs *ExternalDocs                  `json:"externalDocs,omitempty"` // Additional documentation for the method
+	Parameters   []Parameter                    `json:"parameters,omitempty"`   // Parameters that the method may be called with
+	Responses    map[string]Response            `json:"responses,omitempty"`    // Expected responses for call in the form of `["HTTP code"]description`
+	RequestBody  `json:"requestBody,omitempty"` // Body of the Response, if any
+	Deprecated   bool                           `json:"deprecated,omitempty"` // Is the method being phased out?
+
+	// Security overrides API.Security for the method, if not nil.
+	// An empty Security disables authentication for the method.
+	Security []map[string][]string `json:"security,omitempty"`
+
+	Servers []Server `json:"servers,omitempty"` // Overrides API.Servers for the method, if not empty
+
+	// Callbacks holds the requests the API may make in response to the operation, by name, as-is.
+	// References within them are not followed, as they are not modeled.
+	Callbacks map[string]json.RawMessage `json:"callbacks,omitempty"`
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
 }
 
-// Content is the "content" structure within an HTTP request or response.
-type Content map[string]map[string]Schema
+// PathItem is the operations available on a single path, and the fields they share.
+type PathItem struct {
+	Ref         string      `json:"$ref,omitempty"`        // Reference to a path item defined elsewhere
+	Summary     string      `json:"summary,omitempty"`     // Summary for all operations on the path
+	Description string      `json:"description,omitempty"` // Description for all operations on the path
+	Servers     []Server    `json:"servers,omitempty"`     // Overrides API.Servers for all operations on the path, if not empty
+	Parameters  []Parameter `json:"parameters,omitempty"`  // Parameters for all operations on the path
+
+	// Methods holds the operations on the path, keyed by HTTP verb, such as "get".
+	// In JSON, each is a member of the path item alongside the fields above.
+	Methods map[string]Method `json:"-"`
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
+}
+
+// Content is the "content" structure within an HTTP request or response, keyed by media type — ex. "application/json".
+type Content map[string]MediaType
+
+// MediaType describes the body of an HTTP request or response for a given media type.
+type MediaType struct {
+	Schema   Schema              `json:"schema"`             // Describes the body
+	Example  json.RawMessage     `json:"example,omitempty"`  // Example of the body
+	Examples map[string]Example  `json:"examples,omitempty"` // Named examples of the body, ⊻ with Example
+	Encoding map[string]Encoding `json:"encoding,omitempty"` // Serialization of properties of the body, by name, for multipart and form bodies
+}
+
+// Encoding describes how a property of a multipart or form body is serialized.
+type Encoding struct {
+	ContentType   string            `json:"contentType,omitempty"`   // Media type of the property, such as "image/png"
+	Headers       map[string]Header `json:"headers,omitempty"`       // Headers of the part, for multipart bodies
+	Style         string            `json:"style,omitempty"`         // How the property is serialized, as by Parameter.Style, for form bodies
+	Explode       *bool             `json:"explode,omitempty"`       // Are array and object values split into separate properties? Nil defers to Style's default
+	AllowReserved bool              `json:"allowReserved,omitempty"` // Are reserved characters sent unencoded?
+}
+
+// Example is an example value, such as of a body.
+type Example struct {
+	Ref           string          `json:"$ref,omitempty"`          // Reference to an example in API.Examples, in place of a definition
+	Summary       string          `json:"summary,omitempty"`       // Short description of the example
+	Description   string          `json:"description,omitempty"`   // Long description of the example
+	Value         json.RawMessage `json:"value,omitempty"`         // The example itself
+	ExternalValue string          `json:"externalValue,omitempty"` // URL of the example, ⊻ with Value
+}
 
 // RequestBody represents the structure of a request body for HTTP methods such as POST.
 type RequestBody struct {
-	Description string           `json:"description"` // What does the body represent
+	Ref         string           `json:"$ref,omitempty"`        // Reference to a request body in API.RequestBodies, in place of a definition
+	Description string           `json:"description,omitempty"` // What does the body represent
 	Content     `json:"content"` // Contents of body
-	Required    bool             `json:"required"` // Is the body mandatory?
+	Required    bool             `json:"required,omitempty"` // Is the body mandatory?
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
+}
+
+// Header describes an HTTP header, such as one sent with a Response.
+type Header struct {
+	Ref         string          `json:"$ref,omitempty"`        // Reference to a header in API.Headers, in place of a definition
+	Description string          `json:"description,omitempty"` // What does this header represent?
+	Required    bool            `json:"required,omitempty"`    // Is the header always present?
+	Deprecated  bool            `json:"deprecated,omitempty"`  // Is the header being phased out?
+	Schema      Schema          `json:"schema"`                // Describes the type and value scheme of the header, omitted if empty
+	Content     Content         `json:"content,omitempty"`     // Describes the header by media type, ⊻ with Schema
+	Example     json.RawMessage `json:"example,omitempty"`     // Example of the header's value
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
 }
 
 // Parameter describes how a given API parameter should be provided and valued.
 type Parameter struct {
-	Name        string          `json:"name"`        // Parameter name — ex. "accountId"
-	In          string          `json:"in"`          // Where the parameter occurs in the HTTP call
-	Description string          `json:"description"` // What does this parameter represent?
-	Required    bool            `json:"required"`    // Is the parameter mandatory?
+	Ref         string          `json:"$ref,omitempty"`        // Reference to a parameter in API.Parameters, in place of a definition
+	Name        string          `json:"name"`                  // Parameter name — ex. "accountId"
+	In          string          `json:"in"`                    // Where the parameter occurs in the HTTP call
+	Description string          `json:"description,omitempty"` // What does this parameter represent?
+	Required    bool            `json:"required,omitempty"`    // Is the parameter mandatory?
+	Deprecated  bool            `json:"deprecated,omitempty"`  // Is the parameter being phased out?
+	Style       string          `json:"style,omitempty"`       // How the value is serialized — ex. "form", "simple", "deepObject"
+	Explode     *bool           `json:"explode,omitempty"`     // Are array and object values split into separate parameters? Nil defers to Style's default
 	Schema      `json:"schema"` // Describes the type and value scheme of a parameter
+
+	AllowEmptyValue bool `json:"allowEmptyValue,omitempty"` // May a query parameter be sent without a value?
+
+	Example  json.RawMessage    `json:"example,omitempty"`  // Example of the parameter's value
+	Examples map[string]Example `json:"examples,omitempty"` // Named examples of the parameter's value, ⊻ with Example
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
 }
 
 // Response holds information about an HTTP response.
 type Response struct {
-	Description string `json:"description"` // What the response provides
+	Ref         string `json:"$ref,omitempty"` // Reference to a response in API.Responses, in place of a definition
+	Description string `json:"description"`    // What the response provides
+
+	// Content has the structure `[content-type]MediaType`.
+	Content `json:"content,omitempty"` // Contents of the response
 
-	// Content has the structure `[content-type]["schema"]Schema`.
-	Content `json:"content"` // Contents of the response
+	Headers map[string]Header `json:"headers,omitempty"` // Headers of the response, by name
+	Links   map[string]Link   `json:"links,omitempty"`   // Operations which may follow the response, by name
+
+	Extensions map[string]json.RawMessage `json:"-"` // Specification extensions, keyed by their "x-" name
+}
+
+// Link describes an operation which may follow a response, and how to call it with values from the response or its request.
+type Link struct {
+	Ref          string            `json:"$ref,omitempty"`         // Reference to a link in API.Links, in place of a definition
+	OperationRef string            `json:"operationRef,omitempty"` // Reference to the operation, such as "#/paths/~1pets~1{id}/get", ⊻ with OperationID
+	OperationID  string            `json:"operationId,omitempty"`  // OperationID of the operation
+	Parameters   map[string]string `json:"parameters,omitempty"`   // Values of the operation's parameters by name, such as "$response.body#/id"
+	Description  string            `json:"description,omitempty"`  // What following the link does
 }
 
 // Parse takes a io.Reader which provides an OpenAPI v3 JSON specification and deserializes to an API.
+// Errors in decoding the specification are a *ParseError.
+// No limits are placed on the size or depth of the input, see ParseWithOptions for untrusted input.
 func Parse(r io.Reader) (API, error) {
-	br := bufio.NewReader(r)
+	return ParseWithOptions(r, ParseOptions{})
+}
+
+// ParseContext is Parse, but stops once ctx is done, returning ctx.Err().
+// Reading r stops between reads, so that a slow or huge input is abandoned promptly.
+// Decoding the input once read stops before each path item, schema, and other kind of component, so that ParseContext returns soon after ctx is done.
+func ParseContext(ctx context.Context, r io.Reader) (API, error) {
+	return parse(ctx, &contextReader{ctx: ctx, r: r}, ParseOptions{})
+}
+
+// contextReader is a reader which fails with the error of its context once the context is done.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read implements io.Reader.
+func (cr *contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// ParseBytes deserializes the OpenAPI v3 JSON specification in b to an API.
+func ParseBytes(b []byte) (API, error) {
+	return Parse(bytes.NewReader(b))
+}
+
+// ParseString deserializes the OpenAPI v3 JSON specification in s to an API.
+func ParseString(s string) (API, error) {
+	return Parse(strings.NewReader(s))
+}
+
+// ParseRaw deserializes the OpenAPI v3 JSON specification in msg to an API, such as one held within a larger document.
+// Errors are as by Parse.
+func ParseRaw(msg json.RawMessage) (API, error) {
+	return decode(context.Background(), msg, ParseOptions{})
+}
+
+// ParseFile opens the OpenAPI v3 JSON specification file at path and deserializes it to an API.
+func ParseFile(path string) (API, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return API{}, err
+	}
+	defer f.Close()
+
+	api, err := Parse(f)
+	if err != nil {
+		return api, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	return api, nil
+}
+
+// ParseURL fetches the OpenAPI v3 JSON specification at url using http.DefaultClient and deserializes it to an API.
+func ParseURL(ctx context.Context, url string) (API, error) {
+	return ParseURLWithClient(ctx, http.DefaultClient, url)
+}
+
+// ParseURLWithClient is ParseURL, but performs the request with client.
+// This permits callers to provide authentication, a custom transport, etc.
+func ParseURLWithClient(ctx context.Context, client *http.Client, url string) (API, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return API{}, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return API{}, err
+	}
+	defer resp.Body.Close()
 
-	var api API
+	if resp.StatusCode != http.StatusOK {
+		return API{}, fmt.Errorf("get %s: unexpected status %s", url, resp.Status)
+	}
 
-	dec := json.NewDecoder(br)
-	err := dec.Decode(&api)
+	api, err := Parse(resp.Body)
+	if err != nil {
+		return api, fmt.Errorf("parse %s: %w", url, err)
+	}
 
-	return api, err
+	return api, nil
 }
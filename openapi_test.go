@@ -0,0 +1,156 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testSpec exercises most of the API structure, including components of each kind.
+const testSpec = `{
+	"openapi": "3.0.3",
+	"info": {"title": "Pets", "version": "1.0.0", "x-audience": "public"},
+	"servers": [{"url": "https://pets.example.com/v1", "x-ms-parameterized-host": false}],
+	"tags": [{"name": "pets", "x-displayName": "Pets"}],
+	"paths": {
+		"/pets/{id}": {
+			"parameters": [{"$ref": "#/components/parameters/Id"}],
+			"get": {
+				"operationId": "getPet",
+				"summary": "Get a pet",
+				"tags": ["pets"],
+				"parameters": [{"name": "verbose", "in": "query", "allowEmptyValue": true, "schema": {"type": "boolean"}}],
+				"callbacks": {"changed": {"{$request.query.url}": {"post": {"responses": {"200": {"description": "ok"}}}}}},
+				"responses": {
+					"200": {
+						"description": "The pet",
+						"headers": {"X-Rate-Limit": {"$ref": "#/components/headers/RateLimit"}},
+						"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}},
+						"links": {"owner": {"$ref": "#/components/links/Owner"}}
+					},
+					"404": {"$ref": "#/components/responses/NotFound"}
+				}
+			},
+			"put": {
+				"operationId": "putPet",
+				"tags": ["pets"],
+				"requestBody": {
+					"required": true,
+					"x-ms-requestBody-name": "pet",
+					"content": {
+						"application/json": {"schema": {"$ref": "#/components/schemas/Pet", "x-ms-client-flatten": true}},
+						"multipart/form-data": {
+							"schema": {"type": "object", "properties": {"photo": {"type": "string", "format": "binary"}}},
+							"encoding": {"photo": {"contentType": "image/png", "headers": {"X-Rate-Limit": {"$ref": "#/components/headers/RateLimit"}}}}
+						}
+					}
+				},
+				"responses": {"204": {"description": "Replaced"}}
+			}
+		}
+	},
+	"components": {
+		"schemas": {
+			"Pet": {
+				"type": "object",
+				"required": ["id"],
+				"minProperties": 1,
+				"maxProperties": 10,
+				"xml": {"name": "pet"},
+				"example": {"id": 1, "status": "sold"},
+				"properties": {
+					"id": {"type": "integer", "format": "int64", "x-ms-client-name": "petId"},
+					"status": {"$ref": "#/components/schemas/Status"},
+					"tags": {"$ref": "#/components/schemas/Tags"},
+					"kind": {"type": "string", "const": "pet", "xml": {"attribute": true}}
+				}
+			},
+			"Status": {"type": "string", "enum": ["available", "sold"], "default": "available",
+				"x-ms-enum": {"name": "Status", "modelAsString": true}},
+			"Tags": {"type": "array", "items": {"type": "string", "x-ms-client-name": "tag", "xml": {"name": "tag"}},
+				"maxItems": 10, "uniqueItems": true, "readOnly": true, "deprecated": true}
+		},
+		"parameters": {"Id": {"name": "id", "in": "path", "required": true, "schema": {"type": "integer"}}},
+		"responses": {"NotFound": {"description": "No such pet"}},
+		"headers": {"RateLimit": {"description": "Requests left", "schema": {"type": "integer"}}},
+		"links": {"Owner": {"operationId": "getPet", "parameters": {"id": "$response.body#/id"}}},
+		"callbacks": {"Adopted": {"{$request.body#/url}": {"post": {"responses": {"200": {"description": "ok"}}}}}},
+		"x-internal": true
+	}
+}`
+
+func TestParseBytes(t *testing.T) {
+	want, err := Parse(bytes.NewReader([]byte(testSpec)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fromBytes, err := ParseBytes([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromBytes, want) {
+		t.Errorf("ParseBytes differs from Parse:\n%+v\n%+v", fromBytes, want)
+	}
+
+	fromString, err := ParseString(testSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(fromString, want) {
+		t.Errorf("ParseString differs from Parse:\n%+v\n%+v", fromString, want)
+	}
+}
+
+// countdownContext is a context which is done once Err has been called n times.
+type countdownContext struct {
+	context.Context
+	n int
+}
+
+// Err implements context.Context.
+func (c *countdownContext) Err() error {
+	if c.n--; c.n < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestParseContext(t *testing.T) {
+	var spec strings.Builder
+	spec.WriteString(`{"openapi": "3.0.3", "paths": {`)
+	for i := 0; i < 10000; i++ {
+		fmt.Fprintf(&spec, `"/p%d": {"get": {"responses": {"200": {"description": "ok"}}}}, `, i)
+	}
+	spec.WriteString(`"/p": {}}}`)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		err  error
+	}{
+		{"not done", context.Background(), nil},
+		{"done before reading", &countdownContext{Context: context.Background()}, context.Canceled},
+		// Reading takes far fewer checks than there are paths, so this is done while decoding
+		{"done while decoding", &countdownContext{Context: context.Background(), n: 1000}, context.Canceled},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			api, err := ParseContext(test.ctx, strings.NewReader(spec.String()))
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if err == nil && len(api.Paths) != 10001 {
+				t.Errorf("expected 10001 paths, got %d", len(api.Paths))
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// FindOperationByID returns the path, HTTP verb, and Method of the operation with the given operationId.
+// Operation IDs are meant to be unique, if several operations share an ID, the first in path order is returned.
+// ValidateOperationIDs reports such duplicates.
+func (a API) FindOperationByID(id string) (path string, verb string, m Method, ok bool) {
+	a.ForEachOperation(func(p, v string, op Method) {
+		if !ok && op.OperationID == id {
+			path, verb, m, ok = p, v, op, true
+		}
+	})
+
+	return path, verb, m, ok
+}
+
+// LinkedOperation returns the operation which the link l refers to, by OperationID or OperationRef.
+// Only references within the document, such as "#/paths/~1pets~1{id}/get", are supported.
+func (a API) LinkedOperation(l Link) (Operation, error) {
+	if l.OperationID != "" {
+		path, verb, m, ok := a.FindOperationByID(l.OperationID)
+		if !ok {
+			return Operation{}, fmt.Errorf("%s: no such operation", l.OperationID)
+		}
+		return Operation{Path: path, Verb: verb, Method: m}, nil
+	}
+
+	ref := l.OperationRef
+	if ref == "" {
+		return Operation{}, errors.New("link has neither operationId nor operationRef")
+	}
+	if !strings.HasPrefix(ref, "#/") {
+		return Operation{}, fmt.Errorf("%s: not a reference within the document", ref)
+	}
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return Operation{}, fmt.Errorf("%s: %w", ref, err)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	if len(tokens) != 3 || tokens[0] != "paths" {
+		return Operation{}, fmt.Errorf("%s: not a reference to an operation", ref)
+	}
+	path, verb := unescapeToken(tokens[1]), unescapeToken(tokens[2])
+
+	m, ok := a.Paths[path].Methods[verb]
+	if !ok {
+		return Operation{}, fmt.Errorf("%s: no such operation", ref)
+	}
+	return Operation{Path: path, Verb: verb, Method: m}, nil
+}
+
+// ForEachOperation calls fn for each operation in Paths.
+// Paths are visited in lexical order and the operations of each path in the order the OpenAPI specification lists HTTP methods.
+func (a API) ForEachOperation(fn func(path, verb string, m Method)) {
+	for _, path := range a.SortedPaths() {
+		for _, verb := range a.SortedVerbs(path) {
+			fn(path, verb, a.Paths[path].Methods[verb])
+		}
+	}
+}
+
+// MethodsByPath returns the operations of Paths keyed by path, then by HTTP verb, as Paths was before PathItem.
+// The fields of each PathItem shared by its operations are omitted.
+func (a API) MethodsByPath() map[string]map[string]Method {
+	if a.Paths == nil {
+		return nil
+	}
+
+	methods := make(map[string]map[string]Method, len(a.Paths))
+	for path, item := range a.Paths {
+		methods[path] = item.Methods
+	}
+	return methods
+}
+
+// SortedPaths returns the keys of Paths in lexical order.
+func (a API) SortedPaths() []string {
+	return sortedKeys(a.Paths)
+}
+
+// SortedVerbs returns the HTTP verbs defined for path in the order the OpenAPI specification lists them: get, put, post, delete, options, head, patch, trace.
+// Keys which are not HTTP methods follow, in lexical order.
+func (a API) SortedVerbs(path string) []string {
+	return sortedVerbs(a.Paths[path].Methods)
+}
+
+// sortedVerbs returns the keys of methods in the order of verbs.
+// Keys which are not HTTP methods follow, in lexical order.
+func sortedVerbs(methods map[string]Method) []string {
+	keys := make([]string, 0, len(methods))
+	for _, verb := range verbs {
+		if _, ok := methods[verb]; ok {
+			keys = append(keys, verb)
+		}
+	}
+	for _, k := range sortedKeys(methods) {
+		if !isVerb(k) {
+			keys = append(keys, k)
+		}
+	}
+
+	return keys
+}
+
+// Operation is a single operation of an API, identified by its path and HTTP verb.
+type Operation struct {
+	Path   string
+	Verb   string
+	Method Method
+}
+
+// Operations returns every operation of the API, in the order of ForEachOperation: by path, then in the order the OpenAPI specification lists HTTP methods.
+func (a API) Operations() []Operation {
+	var ops []Operation
+	a.ForEachOperation(func(path, verb string, m Method) {
+		ops = append(ops, Operation{Path: path, Verb: verb, Method: m})
+	})
+
+	return ops
+}
+
+// OperationsByTag returns the operations of the API grouped by their tags.
+// Operations with several tags appear under each, untagged operations appear under "".
+// Within each tag, operations are in the order of Operations.
+func (a API) OperationsByTag() map[string][]Operation {
+	groups := make(map[string][]Operation)
+	for _, op := range a.Operations() {
+		if len(op.Method.Tags) < 1 {
+			groups[""] = append(groups[""], op)
+			continue
+		}
+		for _, tag := range op.Method.Tags {
+			if !containsOperation(groups[tag], op) {
+				groups[tag] = append(groups[tag], op)
+			}
+		}
+	}
+
+	return groups
+}
+
+// containsOperation reports whether ops includes the operation at the path and verb of op.
+func containsOperation(ops []Operation, op Operation) bool {
+	for _, o := range ops {
+		if o.Path == op.Path && o.Verb == op.Verb {
+			return true
+		}
+	}
+	return false
+}
+
+// MediaTypes returns the media types of every request body and response in the API, deduplicated and in lexical order.
+// Media types are returned as written, including wildcards and parameters, such as "application/*" or "application/json; charset=utf-8".
+func (a API) MediaTypes() []string {
+	seen := make(map[string]bool)
+	walk(reflect.ValueOf(a), "", func(v reflect.Value, pointer string) error {
+		if c, ok := v.Interface().(Content); ok {
+			for mediaType := range c {
+				seen[mediaType] = true
+			}
+		}
+		return nil
+	})
+
+	return sortedKeys(seen)
+}
+
+// ParametersIn returns the parameters of m whose location is location, one of "query", "path", "header", or "cookie", in the order written.
+// References are not resolved, and the parameters of the PathItem are not included, see API.EffectiveParametersIn for both.
+func (m Method) ParametersIn(location string) []Parameter {
+	return parametersIn(m.Parameters, location)
+}
+
+// Location returns where the parameter occurs, In, in lower case and without surrounding space, such as "cookie" for "Cookie".
+func (p Parameter) Location() string {
+	return strings.ToLower(strings.TrimSpace(p.In))
+}
+
+// parametersIn returns the parameters of params whose location is location, in order.
+func parametersIn(params []Parameter, location string) []Parameter {
+	var out []Parameter
+	for _, p := range params {
+		if p.Location() == location {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// successPattern matches a successful status code, such as "204", or the range of them, "2XX", as statusPattern does for any status.
+var successPattern = regexp.MustCompile(`^2(\d\d|XX)$`)
+
+// OperationsWithoutSuccess returns the operations which declare no successful response: no 2xx status code, "2XX" range, or "default".
+// Operations are in the order of ForEachOperation.
+func (a API) OperationsWithoutSuccess() []Operation {
+	var ops []Operation
+	a.ForEachOperation(func(path, verb string, m Method) {
+		for code := range m.Responses {
+			if successPattern.MatchString(code) || code == "default" {
+				return
+			}
+		}
+		ops = append(ops, Operation{Path: path, Verb: verb, Method: m})
+	})
+	return ops
+}
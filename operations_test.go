@@ -0,0 +1,36 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import "testing"
+
+func TestOperationsWithoutSuccess(t *testing.T) {
+	tests := []struct {
+		codes   []string
+		success bool
+	}{
+		{[]string{"200"}, true},
+		{[]string{"204"}, true},
+		{[]string{"2XX"}, true},
+		{[]string{"default"}, true},
+		{[]string{"404", "201"}, true},
+		{[]string{"404"}, false},
+		{[]string{"2000"}, false},
+		{[]string{"2XXX"}, false},
+		{[]string{"2xx"}, false},
+		{nil, false},
+	}
+
+	for _, test := range tests {
+		responses := make(map[string]Response)
+		for _, code := range test.codes {
+			responses[code] = Response{Description: "d"}
+		}
+		api := API{Paths: map[string]PathItem{"/a": {Methods: map[string]Method{"get": {Responses: responses}}}}}
+
+		if ops := api.OperationsWithoutSuccess(); (len(ops) == 0) != test.success {
+			t.Errorf("%v: expected success %v, got operations without success %v", test.codes, test.success, ops)
+		}
+	}
+}
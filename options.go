@@ -0,0 +1,177 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// RecommendedMaxDepth is a depth to which objects and arrays may nest, for ParseOptions.MaxDepth, suited to untrusted input.
+// Real specifications nest far less deeply, this guards against input crafted to exhaust the stack.
+// Each nested schema is decoded by its own UnmarshalJSON, which scans its input afresh, so the time to decode grows with depth times size.
+const RecommendedMaxDepth = 128
+
+// RecommendedMaxSize is a size in bytes of a specification, for ParseOptions.MaxSize, suited to untrusted input.
+const RecommendedMaxSize = 64 << 20
+
+// Logger receives debug events, such as from ParseWithOptions.
+// A *log.Logger is a Logger.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// ParseOptions configures ParseWithOptions.
+// The zero value is the behavior of Parse, and ParseOptions{Strict: true} that of ParseStrict.
+type ParseOptions struct {
+	Strict      bool  // Reject fields which the API structure does not model, as by ParseStrict
+	MaxDepth    int   // Depth to which objects and arrays may nest, unlimited if 0 or negative
+	MaxSize     int64 // Size of the specification in bytes, unlimited if 0 or negative
+	ResolveRefs bool  // Replace references by their definitions, as by Dereference
+
+	// Logger, if not nil, receives debug events, such as the size of the input, fields skipped for not being modeled, and references resolved.
+	Logger Logger
+}
+
+// ParseWithOptions is Parse, configured by opts.
+// It is intended for untrusted input: a specification exceeding the limits of opts is an error, and decoding never panics.
+// No limits apply unless set, such as to RecommendedMaxSize and RecommendedMaxDepth.
+//
+// The options apply in order, and the first to fail produces the error:
+//   - MaxSize, before the specification is decoded
+//   - MaxDepth, before the specification is decoded
+//   - Strict, as the specification is decoded
+//   - ResolveRefs, once the specification is decoded
+//
+// Errors in decoding the specification, including exceeding MaxDepth, are a *ParseError.
+// Unknown fields found by Strict and unresolvable references are not.
+// Logging is done only if Logger is set, and finding the fields to log as skipped costs a second pass over the input.
+func ParseWithOptions(r io.Reader, opts ParseOptions) (API, error) {
+	return parse(context.Background(), r, opts)
+}
+
+// parse is ParseWithOptions, failing with ctx.Err() if ctx is done while decoding, or between reading, decoding, and resolving references.
+func parse(ctx context.Context, r io.Reader, opts ParseOptions) (API, error) {
+	if opts.MaxSize > 0 {
+		r = io.LimitReader(r, opts.MaxSize+1)
+	}
+	b, err := io.ReadAll(bufio.NewReader(r))
+	if err != nil {
+		return API{}, err
+	}
+	if opts.Logger != nil {
+		opts.Logger.Printf("read %d bytes", len(b))
+	}
+	if err := ctx.Err(); err != nil {
+		return API{}, err
+	}
+
+	api, err := decode(ctx, b, opts)
+	if err != nil {
+		return api, err
+	}
+	if err := ctx.Err(); err != nil {
+		return API{}, err
+	}
+	if opts.Logger != nil {
+		s := api.Stats()
+		opts.Logger.Printf("decoded %d paths, %d operations, %d schemas", s.Paths, s.Operations, s.Components["schemas"])
+	}
+	if !opts.ResolveRefs {
+		return api, nil
+	}
+
+	return api.dereference(opts.Logger)
+}
+
+// decode deserializes the OpenAPI v3 JSON specification in b to an API, within the limits of opts.
+// The limits are checked once over all of b, before anything is decoded.
+// Decoding fails with ctx.Err() once ctx is done, as checked by API.unmarshal.
+func decode(ctx context.Context, b []byte, opts ParseOptions) (api API, err error) {
+	if opts.MaxSize > 0 && int64(len(b)) > opts.MaxSize {
+		return api, fmt.Errorf("specification exceeds %d bytes", opts.MaxSize)
+	}
+	if opts.MaxDepth > 0 {
+		if err := checkDepth(b, opts.MaxDepth); err != nil {
+			return api, err
+		}
+	}
+
+	// A panic in decoding is a bug, but should not take down a caller parsing untrusted input
+	defer func() {
+		if v := recover(); v != nil {
+			api, err = API{}, &ParseError{Offset: -1, Err: fmt.Errorf("malformed specification: %v", v)}
+		}
+	}()
+
+	// The decoder finds syntax errors over all of b, reporting their offsets in b, before anything is unmarshaled
+	var raw json.RawMessage
+	if err := json.NewDecoder(bytes.NewReader(b)).Decode(&raw); err != nil {
+		return api, newParseError(b, err)
+	}
+	if err := api.unmarshal(ctx, raw); err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return API{}, ctxErr
+		}
+		return api, newParseError(b, err)
+	}
+	if !opts.Strict && opts.Logger == nil {
+		return api, nil
+	}
+
+	// Types with their own UnmarshalJSON do not heed DisallowUnknownFields, so check the document by hand,
+	// failing on the first unknown field if strict, else logging each
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return api, err
+	}
+
+	// Strict permits fields of the specification which are not modeled, though they are logged as skipped
+	found := func(err error) error {
+		if errors.Is(err, errUnmodeled) {
+			return nil
+		}
+		return err
+	}
+	if !opts.Strict {
+		found = func(err error) error {
+			opts.Logger.Printf("skipped %v", err)
+			return nil
+		}
+	}
+	return api, unknownField(reflect.TypeOf(api), doc, "", found)
+}
+
+// checkDepth returns a *ParseError if objects and arrays in the JSON b nest more than max deep.
+// Custom unmarshalers decode each level afresh, so the depth limit of encoding/json does not apply to the document as a whole.
+func checkDepth(b []byte, max int) error {
+	depth, quoted, escaped := 0, false, false
+	for i, c := range b {
+		switch {
+		case escaped:
+			escaped = false
+		case quoted:
+			escaped = c == '\\'
+			quoted = c != '"'
+		case c == '"':
+			quoted = true
+		case c == '{' || c == '[':
+			if depth++; depth > max {
+				pe := &ParseError{Err: fmt.Errorf("exceeds the maximum depth of %d", max)}
+				pe.locate(b, int64(i)+1)
+				return pe
+			}
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+
+	return nil
+}
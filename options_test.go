@@ -0,0 +1,88 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func FuzzParse(f *testing.F) {
+	f.Add([]byte(`{"openapi": "3.0.3", "info": {"title": "t", "version": "1"},
+		"paths": {"/pets/{id}": {"get": {"parameters": [{"name": "id", "in": "path", "required": true, "schema": {"type": "string"}}],
+			"responses": {"200": {"description": "ok", "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}}}},
+		"components": {"schemas": {"Pet": {"type": "object", "properties": {"id": {"type": "integer"}}}}}}`))
+
+	// Deep nesting, within and beyond RecommendedMaxDepth
+	for _, depth := range []int{RecommendedMaxDepth - 4, RecommendedMaxDepth + 1} {
+		f.Add([]byte(`{"components": {"schemas": {"A": ` +
+			strings.Repeat(`{"type": "object", "properties": {"a": `, depth/2) + `{}` + strings.Repeat(`}}`, depth/2) + `}}}`))
+	}
+	f.Add([]byte(strings.Repeat(`[`, 10000) + strings.Repeat(`]`, 10000)))
+
+	// Large arrays
+	f.Add([]byte(`{"components": {"schemas": {"A": {"type": "string", "enum": [` + strings.Repeat(`"a", `, 10000) + `"a"]}}}}`))
+	f.Add([]byte(`{"servers": [` + strings.Repeat(`{"url": "/"}, `, 10000) + `{}]}`))
+
+	// The limits guard against input which would exhaust the stack, which no recover can catch
+	opts := ParseOptions{MaxDepth: RecommendedMaxDepth, MaxSize: RecommendedMaxSize}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		api, err := ParseWithOptions(bytes.NewReader(b), opts)
+		if err != nil {
+			return
+		}
+		if _, err := api.Marshal(); err != nil {
+			t.Fatalf("marshal parsed specification: %v", err)
+		}
+	})
+}
+
+func TestParseLimits(t *testing.T) {
+	deep := func(depth int) string {
+		return strings.Repeat(`[`, depth) + strings.Repeat(`]`, depth)
+	}
+
+	tests := []struct {
+		name string
+		spec string
+		opts ParseOptions
+		err  string // Substring of the expected error, or empty if none
+	}{
+		{name: "within depth", spec: `{"x-a": ` + deep(RecommendedMaxDepth-1) + `}`, opts: ParseOptions{MaxDepth: RecommendedMaxDepth}},
+		{name: "beyond depth", spec: `{"x-a": ` + deep(RecommendedMaxDepth) + `}`, opts: ParseOptions{MaxDepth: RecommendedMaxDepth}, err: "exceeds the maximum depth of 128"},
+		{name: "unlimited depth", spec: `{"x-a": ` + deep(2*RecommendedMaxDepth) + `}`},
+		{name: "beyond size", spec: `{"openapi": "3.0.3"}`, opts: ParseOptions{MaxSize: 10}, err: "exceeds 10 bytes"},
+		{name: "unlimited size", spec: `{"openapi": "3.0.3", "x-a": "` + strings.Repeat("a", 1000) + `"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := ParseWithOptions(strings.NewReader(test.spec), test.opts)
+			switch {
+			case test.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.err != "" && err == nil:
+				t.Fatalf("expected error containing %q", test.err)
+			case test.err != "" && !strings.Contains(err.Error(), test.err):
+				t.Fatalf("error %q does not contain %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestParseYAMLAliasing(t *testing.T) {
+	// Each level doubles the size of the last, so that a is 2^30 elements once expanded
+	var spec strings.Builder
+	spec.WriteString("x-a0: &a0 [1, 1]\n")
+	for i := 1; i <= 30; i++ {
+		fmt.Fprintf(&spec, "x-a%d: &a%d [*a%d, *a%d]\n", i, i, i-1, i-1)
+	}
+
+	_, err := ParseYAML(strings.NewReader(spec.String()))
+	if err == nil || !strings.Contains(err.Error(), "excessive aliasing") {
+		t.Fatalf("expected excessive aliasing, got %v", err)
+	}
+}
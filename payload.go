@@ -0,0 +1,415 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateBody returns an error for each way the JSON request body of the operation at path and verb does not conform to its schema for contentType.
+// Path may be a path of the API, such as "/pets/{id}", or a request path it matches, such as "/pets/3".
+// Content types are matched as by Content.Match.
+// Schemas are checked for their type, enumerated values, constraints, required and additional properties, and composition, following references.
+func (a API) ValidateBody(path, verb, contentType string, body []byte) []error {
+	p, m, err := a.findOperation(path, verb)
+	if err != nil {
+		return []error{err}
+	}
+	where := strings.ToLower(verb) + " " + p
+
+	rb := m.RequestBody
+	if rb.Ref != "" {
+		if rb, err = a.ResolveRequestBodyRef(rb.Ref); err != nil {
+			return []error{fmt.Errorf("%s: request body: %w", where, err)}
+		}
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if rb.Required {
+			return []error{fmt.Errorf("%s: request body is required", where)}
+		}
+		return nil
+	}
+
+	mt, ok := rb.Content.Match(contentType)
+	if !ok {
+		return []error{fmt.Errorf("%s: request body: unexpected content type %q", where, contentType)}
+	}
+
+	return a.validatePayload(where+": request body", mt.Schema, body)
+}
+
+// ValidateResponse returns an error for each way the JSON body of a response does not conform to the schema the operation at path and verb declares for status and contentType.
+// Path and contentType are matched as by ValidateBody.
+// Status is matched exactly, then by range, such as "2XX", then as "default".
+func (a API) ValidateResponse(path, verb, status, contentType string, body []byte) []error {
+	p, m, err := a.findOperation(path, verb)
+	if err != nil {
+		return []error{err}
+	}
+	where := strings.ToLower(verb) + " " + p
+
+	code, ok := matchStatus(m.Responses, status)
+	if !ok {
+		return []error{fmt.Errorf("%s: undeclared response status %q", where, status)}
+	}
+
+	r, err := a.ResolveResponse(m.Responses[code])
+	if err != nil {
+		return []error{fmt.Errorf("%s: response %s: %w", where, code, err)}
+	}
+
+	if len(r.Content) == 0 {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return []error{fmt.Errorf("%s: response %s: unexpected body", where, code)}
+		}
+		return nil
+	}
+
+	mt, ok := r.Content.Match(contentType)
+	if !ok {
+		return []error{fmt.Errorf("%s: response %s: unexpected content type %q", where, code, contentType)}
+	}
+
+	return a.validatePayload(fmt.Sprintf("%s: response %s", where, code), mt.Schema, body)
+}
+
+// RequestBodySchema returns the schema of the request body of the operation at path and verb for contentType.
+// Path and contentType are matched as by ValidateBody, and references to a request body or schema are followed to their definition.
+// The result is false if there is no such operation or content, or a reference cannot be resolved.
+func (a API) RequestBodySchema(path, verb, contentType string) (Schema, bool) {
+	_, m, err := a.findOperation(path, verb)
+	if err != nil {
+		return Schema{}, false
+	}
+
+	rb := m.RequestBody
+	if rb.Ref != "" {
+		if rb, err = a.ResolveRequestBodyRef(rb.Ref); err != nil {
+			return Schema{}, false
+		}
+	}
+
+	mt, ok := rb.Content.Match(contentType)
+	if !ok {
+		return Schema{}, false
+	}
+	return a.concreteSchema(mt.Schema)
+}
+
+// ResponseSchema returns the schema of the response of the operation at path and verb for status and contentType.
+// Path, status, and contentType are matched as by ValidateResponse, and references to a response or schema are followed to their definition.
+// The result is false if there is no such operation, response, or content, or a reference cannot be resolved.
+func (a API) ResponseSchema(path, verb, status, contentType string) (Schema, bool) {
+	_, m, err := a.findOperation(path, verb)
+	if err != nil {
+		return Schema{}, false
+	}
+
+	code, ok := matchStatus(m.Responses, status)
+	if !ok {
+		return Schema{}, false
+	}
+	r, err := a.ResolveResponse(m.Responses[code])
+	if err != nil {
+		return Schema{}, false
+	}
+
+	mt, ok := r.Content.Match(contentType)
+	if !ok {
+		return Schema{}, false
+	}
+	return a.concreteSchema(mt.Schema)
+}
+
+// concreteSchema follows the reference of s, and of the schema it refers to, to a definition.
+func (a API) concreteSchema(s Schema) (Schema, bool) {
+	var chain []string
+	for ref := s.Ref; ref != ""; {
+		if contains(chain, ref) {
+			return Schema{}, false
+		}
+		chain = append(chain, ref)
+
+		t, err := a.ResolveRef(ref)
+		if err != nil {
+			return Schema{}, false
+		}
+		s, ref = t.schema(), t.Ref
+	}
+
+	return s, true
+}
+
+// matchStatus returns the status code of responses for status: status itself, then its range, such as "2XX", then "default".
+func matchStatus(responses map[string]Response, status string) (string, bool) {
+	candidates := []string{status, "default"}
+	if len(status) == 3 {
+		candidates = []string{status, status[:1] + "XX", "default"}
+	}
+	for _, c := range candidates {
+		if _, ok := responses[c]; ok {
+			return c, true
+		}
+	}
+
+	return "", false
+}
+
+// findOperation returns the path and Method of the operation at path and verb.
+// Path may be a path of the API, or a request path matched by one of its templates.
+func (a API) findOperation(path, verb string) (string, Method, error) {
+	verb = strings.ToLower(verb)
+
+	if _, ok := a.Paths[path]; !ok {
+		if p, ok := matchPath(a.pathMatchers(), path); ok {
+			path = p
+		}
+	}
+
+	item, ok := a.Paths[path]
+	if !ok {
+		return path, Method{}, fmt.Errorf("%s: no such path", path)
+	}
+	m, ok := item.Methods[verb]
+	if !ok {
+		return path, m, fmt.Errorf("%s %s: no such operation", verb, path)
+	}
+
+	return path, m, nil
+}
+
+// validatePayload decodes the JSON body and validates it against s, prefixing errors with where.
+func (a API) validatePayload(where string, s Schema, body []byte) []error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return []error{fmt.Errorf("%s: %w", where, err)}
+	}
+
+	var errs []error
+	for _, err := range a.validateValue(s.property(), v, "") {
+		errs = append(errs, fmt.Errorf("%s: %w", where, err))
+	}
+	return errs
+}
+
+// validateValue returns an error for each way the decoded JSON value v does not conform to p.
+// Pointer is the JSON pointer to v within the body.
+func (a API) validateValue(p Property, v interface{}, pointer string) []error {
+	at := pointer
+	if at == "" {
+		at = "/"
+	}
+	fail := func(format string, args ...interface{}) []error {
+		return []error{fmt.Errorf("%s: %s", at, fmt.Sprintf(format, args...))}
+	}
+
+	// Follow references to a definition, which may itself be a reference
+	var chain []string
+	for ref := p.Ref; ref != ""; ref = p.Ref {
+		if contains(chain, ref) {
+			return fail("circular reference: %s -> %s", strings.Join(chain, " -> "), ref)
+		}
+		chain = append(chain, ref)
+
+		t, err := a.ResolveRef(ref)
+		if err != nil {
+			return fail("%v", err)
+		}
+		p = typeProperty(t)
+	}
+
+	if v == nil {
+		if p.IsNullable() || len(p.Type) == 0 {
+			return nil
+		}
+		return fail("expected %s, got null", strings.Join(p.Type, " or "))
+	}
+
+	if kind := jsonKind(v); len(p.Type) > 0 && !p.Type.accepts(kind) {
+		return fail("expected %s, got %s", strings.Join(p.Type, " or "), kind)
+	}
+
+	var errs []error
+	if len(p.Enums) > 0 {
+		b, _ := marshal(v)
+		if !contains(p.Enums.values(), string(b)) {
+			errs = append(errs, fail("%s is not one of %s", b, strings.Join(p.Enums.values(), ", "))...)
+		}
+	}
+
+	switch n := v.(type) {
+	case json.Number:
+		f, _ := n.Float64()
+		errs = append(errs, p.Constraints.number(at, f)...)
+
+	case string:
+		errs = append(errs, p.Constraints.string(at, n)...)
+
+	case []interface{}:
+		errs = append(errs, p.Constraints.array(at, len(n))...)
+		if p.Items != nil {
+			for i, e := range n {
+				errs = append(errs, a.validateValue(p.Items.property(), e, pointer+"/"+strconv.Itoa(i))...)
+			}
+		}
+
+	case map[string]interface{}:
+		for _, name := range p.Required {
+			if _, ok := n[name]; !ok {
+				errs = append(errs, fail("missing required property %q", name)...)
+			}
+		}
+		for _, name := range sortedKeys(n) {
+			child := pointer + "/" + escapeToken(name)
+			if prop, ok := p.Properties[name]; ok {
+				errs = append(errs, a.validateValue(prop, n[name], child)...)
+				continue
+			}
+
+			ap := p.AdditionalProperties
+			switch {
+			case ap != nil && ap.Property != nil:
+				errs = append(errs, a.validateValue(*ap.Property, n[name], child)...)
+			case ap != nil && !ap.Allowed:
+				errs = append(errs, fail("unexpected property %q", name)...)
+			}
+		}
+	}
+
+	errs = append(errs, a.validateComposition(p.Composition, v, pointer)...)
+
+	return errs
+}
+
+// validateComposition returns an error for each way v does not conform to the composition c.
+func (a API) validateComposition(c Composition, v interface{}, pointer string) []error {
+	at := pointer
+	if at == "" {
+		at = "/"
+	}
+
+	var errs []error
+	for _, t := range c.AllOf {
+		errs = append(errs, a.validateValue(typeProperty(t), v, pointer)...)
+	}
+
+	if len(c.AnyOf) > 0 && a.matches(c.AnyOf, v, pointer) < 1 {
+		errs = append(errs, fmt.Errorf("%s: matches none of anyOf", at))
+	}
+	if len(c.OneOf) > 0 {
+		if n := a.matches(c.OneOf, v, pointer); n != 1 {
+			errs = append(errs, fmt.Errorf("%s: matches %d of oneOf, expected exactly 1", at, n))
+		}
+	}
+	if c.Not != nil && a.matches([]Type{*c.Not}, v, pointer) > 0 {
+		errs = append(errs, fmt.Errorf("%s: matches not", at))
+	}
+
+	return errs
+}
+
+// matches returns how many of types v conforms to.
+func (a API) matches(types []Type, v interface{}, pointer string) int {
+	n := 0
+	for _, t := range types {
+		if len(a.validateValue(typeProperty(t), v, pointer)) == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// number returns an error for each constraint on numbers which f violates.
+func (c Constraints) number(at string, f float64) []error {
+	var errs []error
+	if c.Minimum != nil && (f < *c.Minimum || f == *c.Minimum && c.ExclusiveMinimum != nil && c.ExclusiveMinimum.Bool) {
+		errs = append(errs, fmt.Errorf("%s: %v is less than the minimum %v", at, f, *c.Minimum))
+	}
+	if c.Maximum != nil && (f > *c.Maximum || f == *c.Maximum && c.ExclusiveMaximum != nil && c.ExclusiveMaximum.Bool) {
+		errs = append(errs, fmt.Errorf("%s: %v is greater than the maximum %v", at, f, *c.Maximum))
+	}
+	if c.ExclusiveMinimum != nil && c.ExclusiveMinimum.Value != nil && f <= *c.ExclusiveMinimum.Value {
+		errs = append(errs, fmt.Errorf("%s: %v is not greater than the exclusive minimum %v", at, f, *c.ExclusiveMinimum.Value))
+	}
+	if c.ExclusiveMaximum != nil && c.ExclusiveMaximum.Value != nil && f >= *c.ExclusiveMaximum.Value {
+		errs = append(errs, fmt.Errorf("%s: %v is not less than the exclusive maximum %v", at, f, *c.ExclusiveMaximum.Value))
+	}
+	if c.MultipleOf != nil && *c.MultipleOf != 0 {
+		if q := f / *c.MultipleOf; q != math.Trunc(q) {
+			errs = append(errs, fmt.Errorf("%s: %v is not a multiple of %v", at, f, *c.MultipleOf))
+		}
+	}
+	return errs
+}
+
+// string returns an error for each constraint on strings which s violates.
+func (c Constraints) string(at string, s string) []error {
+	var errs []error
+	n := utf8.RuneCountInString(s)
+	if c.MinLength != nil && n < *c.MinLength {
+		errs = append(errs, fmt.Errorf("%s: length %d is less than the minimum %d", at, n, *c.MinLength))
+	}
+	if c.MaxLength != nil && n > *c.MaxLength {
+		errs = append(errs, fmt.Errorf("%s: length %d is greater than the maximum %d", at, n, *c.MaxLength))
+	}
+	if c.Pattern != "" {
+		re, err := regexp.Compile(c.Pattern)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid pattern %q: %w", at, c.Pattern, err))
+		} else if !re.MatchString(s) {
+			errs = append(errs, fmt.Errorf("%s: %q does not match the pattern %q", at, s, c.Pattern))
+		}
+	}
+	return errs
+}
+
+// array returns an error for each constraint on arrays which an array of n items violates.
+func (c Constraints) array(at string, n int) []error {
+	var errs []error
+	if c.MinItems != nil && n < *c.MinItems {
+		errs = append(errs, fmt.Errorf("%s: %d items is less than the minimum %d", at, n, *c.MinItems))
+	}
+	if c.MaxItems != nil && n > *c.MaxItems {
+		errs = append(errs, fmt.Errorf("%s: %d items is greater than the maximum %d", at, n, *c.MaxItems))
+	}
+	return errs
+}
+
+// accepts reports whether a value of the schema type kind, as returned by jsonKind, is one of the types.
+// Integers are also numbers.
+func (t Types) accepts(kind string) bool {
+	return contains(t, kind) || kind == "integer" && contains(t, "number")
+}
+
+// jsonKind returns the schema type of the decoded JSON value v, such as "object".
+// Numbers without a fractional part are "integer".
+func jsonKind(v interface{}) string {
+	switch n := v.(type) {
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case json.Number:
+		if f, err := n.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	}
+	return "null"
+}
@@ -0,0 +1,55 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+// enumSpec has enums declared on component schemas, rather than on properties.
+const enumSpec = `{"openapi": "3.0.3", "info": {"title": "t", "version": "1"},
+	"paths": {"/pets": {"get": {"responses": {"200": {"description": "ok",
+		"content": {"application/json": {"schema": {"$ref": "#/components/schemas/Pet"}}}}}}}},
+	"components": {"schemas": {
+		"Pet": {"type": "object", "properties": {"status": {"$ref": "#/components/schemas/Status"}}},
+		"Status": {"type": "string", "enum": ["available", "sold"]},
+		"Size": {"type": "integer", "enum": [1, 2, "3"]}}}}`
+
+func TestValidateResponseComponentEnum(t *testing.T) {
+	api, err := ParseString(enumSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		body string
+		err  string // Substring of the expected error, or empty if none
+	}{
+		{body: `{"status": "sold"}`},
+		{body: `{"status": "zzz"}`, err: `/status: "zzz" is not one of "available", "sold"`},
+	}
+
+	for _, test := range tests {
+		errs := api.ValidateResponse("/pets", "get", "200", "application/json", []byte(test.body))
+		switch {
+		case test.err == "" && len(errs) > 0:
+			t.Errorf("%s: unexpected errors: %v", test.body, errs)
+		case test.err != "" && (len(errs) != 1 || !strings.Contains(errs[0].Error(), test.err)):
+			t.Errorf("%s: expected an error containing %q, got %v", test.body, test.err, errs)
+		}
+	}
+}
+
+func TestValidateEnumsComponent(t *testing.T) {
+	api, err := ParseString(enumSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errs := api.ValidateEnums()
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `/components/schemas/Size/enum/2: "3" is not a valid integer`) {
+		t.Fatalf("expected the enum of Size to be invalid, got %v", errs)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ParseWithRaw is Parse, but also returns the original JSON of every value in the specification, keyed by JSON pointer.
+// For example, raw["/components/schemas/Pet"] holds the exact bytes of the Pet schema, including field order and any fields API does not model.
+// The whole specification is keyed by "".
+func ParseWithRaw(r io.Reader) (API, map[string]json.RawMessage, error) {
+	b, err := io.ReadAll(bufio.NewReader(r))
+	if err != nil {
+		return API{}, nil, err
+	}
+
+	api, err := ParseBytes(b)
+	if err != nil {
+		return api, nil, err
+	}
+
+	raw := make(map[string]json.RawMessage)
+	if err := indexRaw(b, "", raw); err != nil {
+		return api, nil, err
+	}
+
+	return api, raw, nil
+}
+
+// indexRaw records b, and each value within it, in raw by JSON pointer.
+// Each value within b is a sub-slice of b rather than a copy, so that indexing takes one pass over b however deeply it nests.
+func indexRaw(b json.RawMessage, pointer string, raw map[string]json.RawMessage) error {
+	if err := indexValue(json.NewDecoder(bytes.NewReader(b)), b, pointer, raw); err != nil {
+		return err
+	}
+	raw[pointer] = b
+	return nil
+}
+
+// indexValue records the next value read by dec from b, and each value within it, in raw by JSON pointer.
+func indexValue(dec *json.Decoder, b []byte, pointer string, raw map[string]json.RawMessage) error {
+	// The decoder consumes the whitespace and separators before a value only as it reads the value
+	start := dec.InputOffset()
+	for start < int64(len(b)) && strings.IndexByte(" \t\r\n:,", b[start]) >= 0 {
+		start++
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			k, _ := key.(string)
+			if err := indexValue(dec, b, pointer+"/"+escapeToken(k), raw); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := indexValue(dec, b, pointer+"/"+strconv.Itoa(i), raw); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil {
+			return err
+		}
+	}
+
+	end := dec.InputOffset()
+	raw[pointer] = b[start:end:end]
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseWithRaw(t *testing.T) {
+	spec := ` {"paths": {"/a/{id}": {"get": {"tags": [ "a" , "b"], "x-b": {"c" : [1, {}]}}}},
+		"components": {"schemas": {"A": {"type":"string",  "x-order": 2}}}} `
+
+	_, raw, err := ParseWithRaw(strings.NewReader(spec))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		pointer string
+		want    string
+	}{
+		{"", spec},
+		{"/paths/~1a~1{id}/get/tags", `[ "a" , "b"]`},
+		{"/paths/~1a~1{id}/get/tags/1", `"b"`},
+		{"/paths/~1a~1{id}/get/x-b", `{"c" : [1, {}]}`},
+		{"/paths/~1a~1{id}/get/x-b/c/0", `1`},
+		{"/paths/~1a~1{id}/get/x-b/c/1", `{}`},
+		{"/components/schemas/A", `{"type":"string",  "x-order": 2}`},
+		{"/components/schemas/A/x-order", `2`},
+	}
+	for _, test := range tests {
+		if got := string(raw[test.pointer]); got != test.want {
+			t.Errorf("%q: expected %s, got %s", test.pointer, test.want, got)
+		}
+	}
+
+	// Every value, as JSON, is keyed
+	var count func(v interface{}) int
+	count = func(v interface{}) int {
+		n := 1
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for _, e := range v {
+				n += count(e)
+			}
+		case []interface{}:
+			for _, e := range v {
+				n += count(e)
+			}
+		}
+		return n
+	}
+	var doc interface{}
+	if err := json.Unmarshal([]byte(spec), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != count(doc) {
+		t.Errorf("expected %d values, got %d", count(doc), len(raw))
+	}
+}
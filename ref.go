@@ -0,0 +1,905 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ResolveRef returns the Type which a reference such as "#/components/schemas/Pet" points to.
+// Only references to components within the same document are supported.
+// References to parameters, responses, request bodies, examples, and headers are resolved by ResolveParameterRef, ResolveResponseRef, ResolveRequestBodyRef, ResolveExampleRef, and ResolveHeaderRef.
+func (a API) ResolveRef(ref string) (Type, error) {
+	kind, name, err := splitRef(ref)
+	if err != nil {
+		return Type{}, err
+	}
+
+	t, ok := a.Components[kind][name]
+	if !ok {
+		return Type{}, fmt.Errorf("%s: no such component", ref)
+	}
+
+	return t, nil
+}
+
+// ResolveParameterRef returns the Parameter which a reference such as "#/components/parameters/limit" points to.
+// A parameter which is itself a reference is followed.
+func (a API) ResolveParameterRef(ref string) (Parameter, error) {
+	var chain []string
+	for {
+		if contains(chain, ref) {
+			return Parameter{}, fmt.Errorf("circular reference: %s -> %s", strings.Join(chain, " -> "), ref)
+		}
+		chain = append(chain, ref)
+
+		kind, name, err := splitRef(ref)
+		if err != nil {
+			return Parameter{}, err
+		}
+
+		p, ok := a.Parameters[name]
+		if kind != "parameters" || !ok {
+			return Parameter{}, fmt.Errorf("%s: no such parameter", ref)
+		}
+		if p.Ref == "" {
+			return p, nil
+		}
+		ref = p.Ref
+	}
+}
+
+// ResolveResponse returns r, or the Response it refers to if it is a reference, as by ResolveResponseRef.
+func (a API) ResolveResponse(r Response) (Response, error) {
+	if r.Ref == "" {
+		return r, nil
+	}
+	return a.ResolveResponseRef(r.Ref)
+}
+
+// ResolveResponseRef returns the Response which a reference such as "#/components/responses/NotFound" points to.
+// A response which is itself a reference is followed.
+func (a API) ResolveResponseRef(ref string) (Response, error) {
+	var chain []string
+	for {
+		if contains(chain, ref) {
+			return Response{}, fmt.Errorf("circular reference: %s -> %s", strings.Join(chain, " -> "), ref)
+		}
+		chain = append(chain, ref)
+
+		kind, name, err := splitRef(ref)
+		if err != nil {
+			return Response{}, err
+		}
+
+		r, ok := a.Responses[name]
+		if kind != "responses" || !ok {
+			return Response{}, fmt.Errorf("%s: no such response", ref)
+		}
+		if r.Ref == "" {
+			return r, nil
+		}
+		ref = r.Ref
+	}
+}
+
+// ResolveRequestBodyRef returns the RequestBody which a reference such as "#/components/requestBodies/Pet" points to.
+// A request body which is itself a reference is followed.
+func (a API) ResolveRequestBodyRef(ref string) (RequestBody, error) {
+	var chain []string
+	for {
+		if contains(chain, ref) {
+			return RequestBody{}, fmt.Errorf("circular reference: %s -> %s", strings.Join(chain, " -> "), ref)
+		}
+		chain = append(chain, ref)
+
+		kind, name, err := splitRef(ref)
+		if err != nil {
+			return RequestBody{}, err
+		}
+
+		b, ok := a.RequestBodies[name]
+		if kind != "requestBodies" || !ok {
+			return RequestBody{}, fmt.Errorf("%s: no such request body", ref)
+		}
+		if b.Ref == "" {
+			return b, nil
+		}
+		ref = b.Ref
+	}
+}
+
+// ResolveHeaderRef returns the Header which a reference such as "#/components/headers/RateLimit" points to.
+// A header which is itself a reference is followed.
+func (a API) ResolveHeaderRef(ref string) (Header, error) {
+	var chain []string
+	for {
+		if contains(chain, ref) {
+			return Header{}, fmt.Errorf("circular reference: %s -> %s", strings.Join(chain, " -> "), ref)
+		}
+		chain = append(chain, ref)
+
+		kind, name, err := splitRef(ref)
+		if err != nil {
+			return Header{}, err
+		}
+
+		h, ok := a.Headers[name]
+		if kind != "headers" || !ok {
+			return Header{}, fmt.Errorf("%s: no such header", ref)
+		}
+		if h.Ref == "" {
+			return h, nil
+		}
+		ref = h.Ref
+	}
+}
+
+// ResolveExampleRef returns the Example which a reference such as "#/components/examples/Cat" points to.
+// An example which is itself a reference is followed.
+func (a API) ResolveExampleRef(ref string) (Example, error) {
+	var chain []string
+	for {
+		if contains(chain, ref) {
+			return Example{}, fmt.Errorf("circular reference: %s -> %s", strings.Join(chain, " -> "), ref)
+		}
+		chain = append(chain, ref)
+
+		kind, name, err := splitRef(ref)
+		if err != nil {
+			return Example{}, err
+		}
+
+		e, ok := a.Examples[name]
+		if kind != "examples" || !ok {
+			return Example{}, fmt.Errorf("%s: no such example", ref)
+		}
+		if e.Ref == "" {
+			return e, nil
+		}
+		ref = e.Ref
+	}
+}
+
+// EffectiveRequired returns the properties required by typ, including those required by the members of its allOf.
+// References are followed, and the result is deduplicated, in the order found.
+func (a API) EffectiveRequired(typ Type) ([]string, error) {
+	var required []string
+	err := a.effectiveRequired(typ, nil, &required)
+	return required, err
+}
+
+// effectiveRequired adds the properties required by t to required.
+// Chain holds the references being followed, a reference back to one of them is an error.
+func (a API) effectiveRequired(t Type, chain []string, required *[]string) error {
+	if t.Ref != "" {
+		if contains(chain, t.Ref) {
+			return fmt.Errorf("circular reference: %s -> %s", strings.Join(chain, " -> "), t.Ref)
+		}
+
+		resolved, err := a.ResolveRef(t.Ref)
+		if err != nil {
+			return err
+		}
+		return a.effectiveRequired(resolved, append(chain, t.Ref), required)
+	}
+
+	for _, name := range t.Required {
+		if !contains(*required, name) {
+			*required = append(*required, name)
+		}
+	}
+
+	for _, member := range t.AllOf {
+		if err := a.effectiveRequired(member, chain, required); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EffectiveParameters returns the parameters of the operation at path and verb, including those of its PathItem, with references resolved.
+// The operation's parameters override those of the PathItem with the same name and location.
+// Those of the PathItem come first, each list in the order written.
+// References which cannot be resolved are kept as-is, ValidateRefs reports them.
+func (a API) EffectiveParameters(path, verb string) []Parameter {
+	item := a.Paths[path]
+	shared := a.resolvedParameters(item.Parameters)
+	own := a.resolvedParameters(item.Methods[verb].Parameters)
+
+	var params []Parameter
+	for _, p := range shared {
+		if _, ok := findParameter(own, p.Name, p.In); !ok || p.Ref != "" {
+			params = append(params, p)
+		}
+	}
+	return append(params, own...)
+}
+
+// EffectiveParametersIn returns the parameters of EffectiveParameters whose location is location, such as "query".
+func (a API) EffectiveParametersIn(path, verb, location string) []Parameter {
+	return parametersIn(a.EffectiveParameters(path, verb), location)
+}
+
+// resolvedParameters returns a copy of params with references resolved.
+// References which cannot be resolved are kept as-is.
+func (a API) resolvedParameters(params []Parameter) []Parameter {
+	if params == nil {
+		return nil
+	}
+
+	out := make([]Parameter, len(params))
+	for i, p := range params {
+		if p.Ref != "" {
+			if resolved, err := a.ResolveParameterRef(p.Ref); err == nil {
+				p = resolved
+			}
+		}
+		out[i] = p
+	}
+	return out
+}
+
+// Dereference returns a copy of the API with every schema, parameter, response, request body, and example reference replaced by the definition it refers to.
+// References which cannot be resolved, or which refer back to themselves, produce an error describing the chain of references.
+// The receiver is not modified.
+func (a API) Dereference() (API, error) {
+	return a.dereference(nil)
+}
+
+// dereference is Dereference, logging each reference resolved to log, if not nil.
+func (a API) dereference(log Logger) (API, error) {
+	d := dereferencer{api: a, done: make(map[string]Type), log: log}
+
+	out := a
+	out.Components = make(map[string]map[string]Type, len(a.Components))
+	for kind, types := range a.Components {
+		out.Components[kind] = make(map[string]Type, len(types))
+		for name := range types {
+			t, err := d.resolve(componentRef(kind, name))
+			if err != nil {
+				return a, err
+			}
+			out.Components[kind][name] = t
+		}
+	}
+
+	if a.Parameters != nil {
+		out.Parameters = make(map[string]Parameter, len(a.Parameters))
+		for name, p := range a.Parameters {
+			p, err := d.parameter(p)
+			if err != nil {
+				return a, fmt.Errorf("%s: %w", componentRef("parameters", name), err)
+			}
+			out.Parameters[name] = p
+		}
+	}
+
+	if a.Responses != nil {
+		out.Responses = make(map[string]Response, len(a.Responses))
+		for name, r := range a.Responses {
+			r, err := d.response(r)
+			if err != nil {
+				return a, fmt.Errorf("%s: %w", componentRef("responses", name), err)
+			}
+			out.Responses[name] = r
+		}
+	}
+
+	if a.RequestBodies != nil {
+		out.RequestBodies = make(map[string]RequestBody, len(a.RequestBodies))
+		for name, b := range a.RequestBodies {
+			b, err := d.requestBody(b)
+			if err != nil {
+				return a, fmt.Errorf("%s: %w", componentRef("requestBodies", name), err)
+			}
+			out.RequestBodies[name] = b
+		}
+	}
+
+	if a.Headers != nil {
+		out.Headers = make(map[string]Header, len(a.Headers))
+		for name, h := range a.Headers {
+			h, err := d.header(h)
+			if err != nil {
+				return a, fmt.Errorf("%s: %w", componentRef("headers", name), err)
+			}
+			out.Headers[name] = h
+		}
+	}
+
+	if a.Examples != nil {
+		examples, err := d.examples(a.Examples)
+		if err != nil {
+			return a, err
+		}
+		out.Examples = examples
+	}
+
+	if a.Paths == nil {
+		return out, nil
+	}
+
+	out.Paths = make(map[string]PathItem, len(a.Paths))
+	for path, item := range a.Paths {
+		if item.Parameters != nil {
+			params := make([]Parameter, len(item.Parameters))
+			for i, p := range item.Parameters {
+				p, err := d.parameter(p)
+				if err != nil {
+					return a, fmt.Errorf("%s: parameter %d: %w", path, i, err)
+				}
+				params[i] = p
+			}
+			item.Parameters = params
+		}
+
+		methods := item.Methods
+		if methods != nil {
+			item.Methods = make(map[string]Method, len(methods))
+		}
+		for verb, m := range methods {
+			m, err := d.method(m)
+			if err != nil {
+				return a, fmt.Errorf("%s %s: %w", verb, path, err)
+			}
+			item.Methods[verb] = m
+		}
+		out.Paths[path] = item
+	}
+
+	return out, nil
+}
+
+// ResolveDiscriminator returns the Type selected by value of a discriminator's property.
+// The Mapping entry for value may be a reference or a schema name.
+// Absent an entry, value is taken as the name of a schema.
+func (a API) ResolveDiscriminator(d Discriminator, value string) (Type, error) {
+	target, ok := d.Mapping[value]
+	if !ok {
+		target = value
+	}
+
+	if !strings.HasPrefix(target, "#") {
+		target = componentRef("schemas", target)
+	}
+
+	return a.ResolveRef(target)
+}
+
+// DetectCycles returns each chain of schema references which loops back on itself.
+// A cycle is the ordered list of references forming the loop, beginning and ending with the same reference.
+// For example: ["#/components/schemas/A", "#/components/schemas/B", "#/components/schemas/A"].
+func (a API) DetectCycles() [][]string {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	var cycles [][]string
+	var stack []string
+	state := make(map[string]int)
+
+	var visit func(ref string)
+	visit = func(ref string) {
+		state[ref] = visiting
+		stack = append(stack, ref)
+
+		t, _ := a.ResolveRef(ref)
+		for _, next := range t.refs() {
+			switch state[next] {
+			case unvisited:
+				visit(next)
+			case visiting:
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i] == next {
+						cycle := append(append([]string{}, stack[i:]...), next)
+						cycles = append(cycles, cycle)
+						break
+					}
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[ref] = visited
+	}
+
+	for _, kind := range sortedKeys(a.Components) {
+		for _, name := range sortedKeys(a.Components[kind]) {
+			if ref := componentRef(kind, name); state[ref] == unvisited {
+				visit(ref)
+			}
+		}
+	}
+
+	return cycles
+}
+
+// refs returns the component references made directly by a Type, sorted and in the form built by componentRef.
+func (t Type) refs() []string {
+	seen := make(map[string]bool)
+	walk(reflect.ValueOf(t), "", func(v reflect.Value, _ string) error {
+		if kind, name, err := splitRef(schemaRef(v)); err == nil {
+			seen[componentRef(kind, name)] = true
+		}
+		return nil
+	})
+
+	refs := make([]string, 0, len(seen))
+	for ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	return refs
+}
+
+// schemaRef returns the reference made by v, if v is a Type, Property, or Schema.
+func schemaRef(v reflect.Value) string {
+	switch n := v.Interface().(type) {
+	case Type:
+		return n.Ref
+	case Property:
+		return n.Ref
+	case Schema:
+		return n.Ref
+	}
+
+	return ""
+}
+
+// dereferencer inlines references, tracking the chain of references being followed.
+type dereferencer struct {
+	api   API
+	chain []string        // References currently being resolved, outermost first
+	done  map[string]Type // Fully dereferenced types by reference
+	log   Logger          // Receives each reference resolved, if not nil
+}
+
+// resolved logs that ref was resolved.
+func (d *dereferencer) resolved(ref string) {
+	if d.log != nil {
+		d.log.Printf("resolved %s", ref)
+	}
+}
+
+// resolve returns the dereferenced Type for ref.
+func (d *dereferencer) resolve(ref string) (Type, error) {
+	if t, ok := d.done[ref]; ok {
+		return t, nil
+	}
+
+	for i, r := range d.chain {
+		if r == ref {
+			cycle := append(append([]string{}, d.chain[i:]...), ref)
+			return Type{}, fmt.Errorf("circular reference: %s", strings.Join(cycle, " -> "))
+		}
+	}
+
+	t, err := d.api.ResolveRef(ref)
+	if err != nil {
+		return Type{}, err
+	}
+
+	d.chain = append(d.chain, ref)
+	t, err = d.typ(t)
+	d.chain = d.chain[:len(d.chain)-1]
+	if err != nil {
+		return Type{}, err
+	}
+
+	d.done[ref] = t
+	d.resolved(ref)
+	return t, nil
+}
+
+func (d *dereferencer) typ(t Type) (Type, error) {
+	if t.Ref != "" {
+		return d.resolve(t.Ref)
+	}
+
+	if t.Items != nil {
+		items, err := d.schema(*t.Items)
+		if err != nil {
+			return t, err
+		}
+		t.Items = &items
+	}
+
+	var err error
+	t.Properties, err = d.properties(t.Properties)
+	if err != nil {
+		return t, err
+	}
+
+	t.AdditionalProperties, err = d.additionalProperties(t.AdditionalProperties)
+	if err != nil {
+		return t, err
+	}
+
+	t.Composition, err = d.composition(t.Composition)
+	return t, err
+}
+
+func (d *dereferencer) additionalProperties(ap *AdditionalProperties) (*AdditionalProperties, error) {
+	if ap == nil || ap.Property == nil {
+		return ap, nil
+	}
+
+	p, err := d.property(*ap.Property)
+	if err != nil {
+		return nil, fmt.Errorf("additionalProperties: %w", err)
+	}
+
+	return &AdditionalProperties{Allowed: true, Property: &p}, nil
+}
+
+func (d *dereferencer) types(types []Type) ([]Type, error) {
+	if types == nil {
+		return nil, nil
+	}
+
+	out := make([]Type, len(types))
+	for i, t := range types {
+		var err error
+		out[i], err = d.typ(t)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+func (d *dereferencer) composition(c Composition) (Composition, error) {
+	var err error
+	if c.AllOf, err = d.types(c.AllOf); err != nil {
+		return c, fmt.Errorf("allOf: %w", err)
+	}
+	if c.OneOf, err = d.types(c.OneOf); err != nil {
+		return c, fmt.Errorf("oneOf: %w", err)
+	}
+	if c.AnyOf, err = d.types(c.AnyOf); err != nil {
+		return c, fmt.Errorf("anyOf: %w", err)
+	}
+	if c.Not != nil {
+		not, err := d.typ(*c.Not)
+		if err != nil {
+			return c, fmt.Errorf("not: %w", err)
+		}
+		c.Not = &not
+	}
+
+	return c, nil
+}
+
+func (d *dereferencer) properties(props map[string]Property) (map[string]Property, error) {
+	if props == nil {
+		return nil, nil
+	}
+
+	out := make(map[string]Property, len(props))
+	for name, p := range props {
+		p, err := d.property(p)
+		if err != nil {
+			return nil, fmt.Errorf("property %s: %w", name, err)
+		}
+		out[name] = p
+	}
+
+	return out, nil
+}
+
+func (d *dereferencer) property(p Property) (Property, error) {
+	if p.Ref != "" {
+		t, err := d.resolve(p.Ref)
+		return t.property(), err
+	}
+
+	if p.Items != nil {
+		s, err := d.schema(*p.Items)
+		if err != nil {
+			return p, err
+		}
+		p.Items = &s
+	}
+
+	var err error
+	p.Properties, err = d.properties(p.Properties)
+	if err != nil {
+		return p, err
+	}
+
+	p.AdditionalProperties, err = d.additionalProperties(p.AdditionalProperties)
+	if err != nil {
+		return p, err
+	}
+
+	p.Composition, err = d.composition(p.Composition)
+	return p, err
+}
+
+func (d *dereferencer) schema(s Schema) (Schema, error) {
+	if s.Ref != "" {
+		t, err := d.resolve(s.Ref)
+		return t.schema(), err
+	}
+
+	if s.Items != nil {
+		items, err := d.schema(*s.Items)
+		if err != nil {
+			return s, err
+		}
+		s.Items = &items
+	}
+
+	var err error
+	s.Properties, err = d.properties(s.Properties)
+	if err != nil {
+		return s, err
+	}
+
+	s.Composition, err = d.composition(s.Composition)
+	return s, err
+}
+
+func (d *dereferencer) content(c Content) (Content, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	out := make(Content, len(c))
+	for name, mt := range c {
+		var err error
+		mt.Schema, err = d.schema(mt.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		mt.Examples, err = d.examples(mt.Examples)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		out[name] = mt
+	}
+
+	return out, nil
+}
+
+func (d *dereferencer) response(r Response) (Response, error) {
+	var err error
+	if r.Ref != "" {
+		ref := r.Ref
+		r, err = d.api.ResolveResponseRef(ref)
+		if err != nil {
+			return r, err
+		}
+		d.resolved(ref)
+	}
+
+	r.Content, err = d.content(r.Content)
+	if err != nil {
+		return r, err
+	}
+
+	if r.Headers == nil {
+		return r, nil
+	}
+
+	headers := make(map[string]Header, len(r.Headers))
+	for name, h := range r.Headers {
+		if h, err = d.header(h); err != nil {
+			return r, fmt.Errorf("header %s: %w", name, err)
+		}
+		headers[name] = h
+	}
+	r.Headers = headers
+
+	return r, nil
+}
+
+// header returns h, or the header it refers to, with references inlined.
+func (d *dereferencer) header(h Header) (Header, error) {
+	var err error
+	if h.Ref != "" {
+		ref := h.Ref
+		h, err = d.api.ResolveHeaderRef(ref)
+		if err != nil {
+			return h, err
+		}
+		d.resolved(ref)
+	}
+
+	h.Schema, err = d.schema(h.Schema)
+	if err != nil {
+		return h, err
+	}
+
+	h.Content, err = d.content(h.Content)
+	return h, err
+}
+
+func (d *dereferencer) parameter(p Parameter) (Parameter, error) {
+	var err error
+	if p.Ref != "" {
+		ref := p.Ref
+		p, err = d.api.ResolveParameterRef(ref)
+		if err != nil {
+			return p, err
+		}
+		d.resolved(ref)
+	}
+
+	p.Schema, err = d.schema(p.Schema)
+	if err != nil {
+		return p, err
+	}
+
+	p.Examples, err = d.examples(p.Examples)
+	return p, err
+}
+
+// examples returns a copy of examples with references resolved.
+func (d *dereferencer) examples(examples map[string]Example) (map[string]Example, error) {
+	if examples == nil {
+		return nil, nil
+	}
+
+	out := make(map[string]Example, len(examples))
+	for name, e := range examples {
+		if e.Ref != "" {
+			var err error
+			ref := e.Ref
+			if e, err = d.api.ResolveExampleRef(ref); err != nil {
+				return nil, fmt.Errorf("example %s: %w", name, err)
+			}
+			d.resolved(ref)
+		}
+		out[name] = e
+	}
+
+	return out, nil
+}
+
+func (d *dereferencer) requestBody(b RequestBody) (RequestBody, error) {
+	var err error
+	if b.Ref != "" {
+		ref := b.Ref
+		b, err = d.api.ResolveRequestBodyRef(ref)
+		if err != nil {
+			return b, err
+		}
+		d.resolved(ref)
+	}
+
+	b.Content, err = d.content(b.Content)
+	return b, err
+}
+
+func (d *dereferencer) method(m Method) (Method, error) {
+	var err error
+
+	if m.Parameters != nil {
+		params := make([]Parameter, len(m.Parameters))
+		for i, p := range m.Parameters {
+			name := p.Name
+			p, err = d.parameter(p)
+			if err != nil && name == "" {
+				return m, fmt.Errorf("parameter %d: %w", i, err)
+			} else if err != nil {
+				return m, fmt.Errorf("parameter %s: %w", name, err)
+			}
+			params[i] = p
+		}
+		m.Parameters = params
+	}
+
+	m.RequestBody, err = d.requestBody(m.RequestBody)
+	if err != nil {
+		return m, fmt.Errorf("request body: %w", err)
+	}
+
+	if m.Responses != nil {
+		responses := make(map[string]Response, len(m.Responses))
+		for code, r := range m.Responses {
+			r, err = d.response(r)
+			if err != nil {
+				return m, fmt.Errorf("response %s: %w", code, err)
+			}
+			responses[code] = r
+		}
+		m.Responses = responses
+	}
+
+	return m, nil
+}
+
+// property converts a Type to the equivalent inline Property.
+func (t Type) property() Property {
+	return Property{
+		Title:                t.Title,
+		Description:          t.Description,
+		Type:                 t.Is,
+		Format:               t.Format,
+		Nullable:             t.Nullable,
+		Items:                t.Items,
+		Enums:                t.Enums,
+		Deprecated:           t.Deprecated,
+		ReadOnly:             t.ReadOnly,
+		WriteOnly:            t.WriteOnly,
+		Default:              t.Default,
+		Example:              t.Example,
+		Const:                t.Const,
+		XML:                  t.XML,
+		Required:             t.Required,
+		Properties:           t.Properties,
+		Constraints:          t.Constraints,
+		Composition:          t.Composition,
+		Discriminator:        t.Discriminator,
+		AdditionalProperties: t.AdditionalProperties,
+		Extensions:           t.Extensions,
+	}
+}
+
+// schema converts a Type to the equivalent inline Schema.
+// Schema has no example, nor discriminator, additional properties, deprecated, read-only, or write-only, so those of the Type are dropped.
+func (t Type) schema() Schema {
+	return Schema{
+		Title:       t.Title,
+		Description: t.Description,
+		Type:        t.Is,
+		Format:      t.Format,
+		Nullable:    t.Nullable,
+		Items:       t.Items,
+		Enums:       t.Enums,
+		Default:     t.Default,
+		Const:       t.Const,
+		XML:         t.XML,
+		Required:    t.Required,
+		Properties:  t.Properties,
+		Constraints: t.Constraints,
+		Composition: t.Composition,
+		Extensions:  t.Extensions,
+	}
+}
+
+// componentRef returns the reference to the component name of the given kind, such as "schemas".
+func componentRef(kind, name string) string {
+	return "#/components/" + url.PathEscape(escapeToken(kind)) + "/" + url.PathEscape(escapeToken(name))
+}
+
+// splitRef splits a reference to a component into the kind of component, such as "schemas", and its name.
+func splitRef(ref string) (kind, name string, err error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return "", "", fmt.Errorf("%s: not a reference within the document", ref)
+	}
+
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return "", "", fmt.Errorf("%s: %w", ref, err)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	if len(tokens) != 3 || tokens[0] != "components" {
+		return "", "", fmt.Errorf("%s: not a reference to a component", ref)
+	}
+
+	return unescapeToken(tokens[1]), unescapeToken(tokens[2]), nil
+}
+
+// escapeToken encodes "~" and "/" in a JSON pointer reference token.
+func escapeToken(token string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(token)
+}
+
+// unescapeToken decodes the "~1" and "~0" escapes in a JSON pointer reference token.
+func unescapeToken(token string) string {
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// RouteStyle is the syntax of the paths of an HTTP router.
+type RouteStyle int
+
+const (
+	// ServeMuxStyle is the syntax of net/http.ServeMux as of Go 1.22, such as "/pets/{id}".
+	// Paths ending in a slash, which ServeMux would match as a prefix, end in "{$}" to match exactly.
+	// Parameter names must be Go identifiers, so other characters are replaced by underscores, as "{pet_id}" for "{pet-id}".
+	ServeMuxStyle RouteStyle = iota
+
+	// ColonStyle is the syntax of routers such as gin, echo, and httprouter, such as "/pets/:id".
+	ColonStyle
+)
+
+// Route is an operation of the API, for registering with an HTTP router.
+type Route struct {
+	Method      string // HTTP method, in upper case, such as "GET"
+	Path        string // Path in the syntax of a RouteStyle
+	OperationID string // OperationID of the operation, if any
+}
+
+// Routes returns a Route for each operation of the API, in the order of ForEachOperation, with paths in the syntax of style.
+// Operations keyed by something other than an HTTP method are skipped.
+// An error is returned for a path which style cannot express, such as "/files/{name}.json", whose parameter is part of a segment.
+func (a API) Routes(style RouteStyle) ([]Route, error) {
+	if style != ServeMuxStyle && style != ColonStyle {
+		return nil, fmt.Errorf("unknown route style %d", style)
+	}
+
+	var routes []Route
+	var err error
+	a.ForEachOperation(func(path, verb string, m Method) {
+		if err != nil || !isVerb(verb) {
+			return
+		}
+
+		var p string
+		if p, err = routePath(path, style); err == nil {
+			routes = append(routes, Route{Method: strings.ToUpper(verb), Path: p, OperationID: m.OperationID})
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return routes, nil
+}
+
+// routePath converts path to the syntax of style.
+func routePath(path string, style RouteStyle) (string, error) {
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if !strings.ContainsAny(seg, "{}") {
+			continue
+		}
+
+		match := templatePattern.FindStringSubmatch(seg)
+		if match == nil || match[0] != seg {
+			return "", fmt.Errorf("%s: parameter is part of the segment %q", path, seg)
+		}
+
+		switch style {
+		case ServeMuxStyle:
+			segments[i] = "{" + wildcardName(match[1]) + "}"
+		case ColonStyle:
+			segments[i] = ":" + match[1]
+		}
+	}
+
+	out := strings.Join(segments, "/")
+	if style == ServeMuxStyle && strings.HasSuffix(out, "/") {
+		out += "{$}"
+	}
+	return out, nil
+}
+
+// wildcardName converts name to a Go identifier, as ServeMux requires of wildcards, replacing other characters by underscores, such as "pet-id" to "pet_id".
+// Names which would begin with a digit are prefixed with an underscore.
+func wildcardName(name string) string {
+	r := []rune(name)
+	for i, c := range r {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) && c != '_' {
+			r[i] = '_'
+		}
+	}
+	if len(r) == 0 || unicode.IsDigit(r[0]) {
+		r = append([]rune{'_'}, r...)
+	}
+	return string(r)
+}
@@ -0,0 +1,70 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoutes(t *testing.T) {
+	api, err := ParseString(`{"paths": {
+		"/": {"get": {"operationId": "root"}},
+		"/pets/": {"get": {"operationId": "listPets"}, "post": {}},
+		"/pets/{pet-id}": {"delete": {"operationId": "deletePet"}, "get": {"operationId": "getPet"}},
+		"/owners/{1st}/pets": {"get": {}}
+	}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		style RouteStyle
+		want  []Route
+	}{
+		{ServeMuxStyle, []Route{
+			{"GET", "/{$}", "root"},
+			{"GET", "/owners/{_1st}/pets", ""},
+			{"GET", "/pets/{$}", "listPets"},
+			{"POST", "/pets/{$}", ""},
+			{"GET", "/pets/{pet_id}", "getPet"},
+			{"DELETE", "/pets/{pet_id}", "deletePet"},
+		}},
+		{ColonStyle, []Route{
+			{"GET", "/", "root"},
+			{"GET", "/owners/:1st/pets", ""},
+			{"GET", "/pets/", "listPets"},
+			{"POST", "/pets/", ""},
+			{"GET", "/pets/:pet-id", "getPet"},
+			{"DELETE", "/pets/:pet-id", "deletePet"},
+		}},
+	}
+
+	for _, test := range tests {
+		got, err := api.Routes(test.style)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("style %d: expected %v, got %v", test.style, test.want, got)
+		}
+	}
+
+	invalid := []struct {
+		path  string
+		style RouteStyle
+		err   string
+	}{
+		{"/files/{name}.json", ServeMuxStyle, `/files/{name}.json: parameter is part of the segment "{name}.json"`},
+		{"/files/v{version}", ColonStyle, `/files/v{version}: parameter is part of the segment "v{version}"`},
+		{"/files", RouteStyle(2), "unknown route style 2"},
+	}
+	for _, e := range invalid {
+		a := API{Paths: map[string]PathItem{e.path: {Methods: map[string]Method{"get": {}}}}}
+		if _, err := a.Routes(e.style); err == nil || !strings.Contains(err.Error(), e.err) {
+			t.Errorf("%s: expected error containing %q, got %v", e.path, e.err, err)
+		}
+	}
+}
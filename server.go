@@ -0,0 +1,106 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expand returns the server's URL with each variable substituted.
+// Values are taken from vars, falling back to the default of each of the server's Variables.
+// A value not permitted by a variable's enumeration, or a variable in the URL which the server does not describe, is an error.
+func (s Server) Expand(vars map[string]string) (string, error) {
+	var b strings.Builder
+
+	rest := s.URL
+	for {
+		open := strings.IndexByte(rest, '{')
+		if open < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return "", fmt.Errorf("%s: unterminated variable", s.URL)
+		}
+		end += open
+
+		name := rest[open+1 : end]
+		v, ok := s.Variables[name]
+		if !ok {
+			return "", fmt.Errorf("%s: undescribed variable %q", s.URL, name)
+		}
+
+		value, ok := vars[name]
+		if !ok {
+			value = v.Default
+		}
+		if len(v.Enums) > 0 && !contains(v.Enums, value) {
+			return "", fmt.Errorf("%s: value %q for variable %q is not one of %s", s.URL, value, name, strings.Join(v.Enums, ", "))
+		}
+
+		b.WriteString(rest[:open])
+		b.WriteString(value)
+		rest = rest[end+1:]
+	}
+	b.WriteString(rest)
+
+	return b.String(), nil
+}
+
+// EffectiveServers returns the servers for the operation at path and verb.
+// The operation's Servers take precedence, then those of its PathItem, then the API's Servers.
+// Absent either, the single server "/", relative to where the specification is served, applies per the OpenAPI specification.
+func (a API) EffectiveServers(path, verb string) []Server {
+	item := a.Paths[path]
+	if m := item.Methods[verb]; len(m.Servers) > 0 {
+		return m.Servers
+	}
+	if len(item.Servers) > 0 {
+		return item.Servers
+	}
+	if len(a.Servers) > 0 {
+		return a.Servers
+	}
+
+	return []Server{{URL: "/"}}
+}
+
+// ResolvedServers returns the URL of every server of the API, expanded as by Server.Expand with vars.
+// The API's Servers come first, then those of each path and operation, in the order of ForEachOperation, without duplicates.
+// A name in vars which no server describes is an error.
+func (a API) ResolvedServers(vars map[string]string) ([]string, error) {
+	servers := append([]Server{}, a.Servers...)
+	for _, path := range a.SortedPaths() {
+		servers = append(servers, a.Paths[path].Servers...)
+		for _, verb := range a.SortedVerbs(path) {
+			servers = append(servers, a.Paths[path].Methods[verb].Servers...)
+		}
+	}
+
+	described := make(map[string]bool)
+	for _, s := range servers {
+		for name := range s.Variables {
+			described[name] = true
+		}
+	}
+	for _, name := range sortedKeys(vars) {
+		if !described[name] {
+			return nil, fmt.Errorf("unknown server variable %q", name)
+		}
+	}
+
+	var urls []string
+	for _, s := range servers {
+		url, err := s.Expand(vars)
+		if err != nil {
+			return nil, err
+		}
+		if !contains(urls, url) {
+			urls = append(urls, url)
+		}
+	}
+
+	return urls, nil
+}
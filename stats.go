@@ -0,0 +1,50 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+)
+
+// Stats counts the surface of an API, such as for tracking its growth over time.
+type Stats struct {
+	Paths      int            // Number of paths
+	Operations int            // Number of operations
+	Verbs      map[string]int // Number of operations by HTTP verb, such as "get"
+	Components map[string]int // Number of components by kind, such as "schemas"
+	Parameters int            // Number of parameters of all operations, including those shared by a path
+	Deprecated int            // Number of deprecated operations
+}
+
+// Stats returns the counts of the paths, operations, components, and parameters of the API.
+// Kinds of components without any are omitted from Components.
+func (a API) Stats() Stats {
+	s := Stats{
+		Paths:      len(a.Paths),
+		Verbs:      make(map[string]int),
+		Components: make(map[string]int),
+	}
+
+	a.ForEachOperation(func(path, verb string, m Method) {
+		s.Operations++
+		s.Verbs[verb]++
+		s.Parameters += len(a.EffectiveParameters(path, verb))
+		if m.Deprecated {
+			s.Deprecated++
+		}
+	})
+
+	for kind, components := range a.Components {
+		if len(components) > 0 {
+			s.Components[kind] += len(components)
+		}
+	}
+	for kind, field := range a.componentFields() {
+		if n := reflect.ValueOf(field).Elem().Len(); n > 0 {
+			s.Components[kind] += n
+		}
+	}
+
+	return s
+}
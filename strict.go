@@ -0,0 +1,207 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ParseStrict is Parse, but rejects a specification containing fields which the API structure does not model.
+// This catches typos such as "propertis" for "properties".
+// Specification extensions, fields beginning with "x-", are permitted on any object.
+// Fields the OpenAPI specification defines which the API structure does not model, such as "webhooks", are permitted, though dropped, and not checked.
+// Kinds of components kept in API.OtherComponents, such as "callbacks", are not checked.
+func ParseStrict(r io.Reader) (API, error) {
+	return ParseWithOptions(r, ParseOptions{Strict: true})
+}
+
+var (
+	apiType        = reflect.TypeOf(API{})
+	pathItemType   = reflect.TypeOf(PathItem{})
+	methodType     = reflect.TypeOf(Method{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// errUnmodeled is wrapped by the errors unknownField finds for fields which the specification defines, but the API structure does not model.
+var errUnmodeled = errors.New("field not modeled")
+
+// schemaKeywords is the keywords of a Schema Object which Type and Property do not model, including those of JSON Schema 2020-12 used by OpenAPI 3.1.
+var schemaKeywords = []string{
+	"externalDocs", "examples", "$schema", "$id", "$anchor", "$dynamicAnchor", "$dynamicRef", "$defs", "$comment",
+	"prefixItems", "contains", "minContains", "maxContains", "unevaluatedItems", "unevaluatedProperties",
+	"patternProperties", "propertyNames", "dependentRequired", "dependentSchemas", "if", "then", "else",
+	"contentEncoding", "contentMediaType", "contentSchema",
+}
+
+// unmodeledFields is, by type, the fields of the corresponding object of the specification which the type does not model.
+var unmodeledFields = map[reflect.Type][]string{
+	apiType:                                {"webhooks", "jsonSchemaDialect"},
+	reflect.TypeOf(Info{}):                 {"summary"},
+	reflect.TypeOf(Type{}):                 schemaKeywords,
+	reflect.TypeOf(Property{}):             schemaKeywords,
+	reflect.TypeOf(AdditionalProperties{}): schemaKeywords,
+	reflect.TypeOf(Schema{}):               append([]string{"additionalProperties", "discriminator", "example", "deprecated", "readOnly", "writeOnly"}, schemaKeywords...),
+	reflect.TypeOf(Parameter{}):            {"allowReserved", "content"},
+	reflect.TypeOf(Header{}):               {"style", "explode", "examples"},
+	reflect.TypeOf(Link{}):                 {"requestBody", "server"},
+}
+
+// unknownField calls found with an error for each member of the decoded JSON value v which has no corresponding field in t,
+// stopping at, and returning, the first error found returns.
+// The error wraps errUnmodeled if the specification defines the member, and its value is not checked.
+// Pointer is the JSON pointer to v within the document.
+func unknownField(t reflect.Type, v interface{}, pointer string, found func(error) error) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == rawMessageType {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		fields := jsonFields(t)
+		for _, k := range sortedKeys(obj) {
+			if strings.HasPrefix(k, "x-") {
+				continue
+			}
+			ft, ok := fields[k]
+			if !ok && t == pathItemType && isVerb(k) {
+				ft, ok = methodType, true
+			}
+			if !ok {
+				err := fmt.Errorf("unknown field %q", k)
+				if contains(unmodeledFields[t], k) {
+					err = fmt.Errorf("%w: %q", errUnmodeled, k)
+				}
+				if pointer != "" {
+					err = fmt.Errorf("%w in %s", err, pointer)
+				}
+				if err := found(err); err != nil {
+					return err
+				}
+				continue
+			}
+			if t == apiType && k == "components" {
+				if err := unknownComponent(obj[k], "/components", found); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := unknownField(ft, obj[k], pointer+"/"+escapeToken(k), found); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		for _, k := range sortedKeys(obj) {
+			if err := unknownField(t.Elem(), obj[k], pointer+"/"+escapeToken(k), found); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		arr, ok := v.([]interface{})
+		if !ok {
+			return nil
+		}
+
+		for i, e := range arr {
+			if err := unknownField(t.Elem(), e, fmt.Sprintf("%s/%d", pointer, i), found); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// componentKinds is the kinds of components an OpenAPI v3 specification may hold.
+var componentKinds = []string{"schemas", "responses", "parameters", "examples", "requestBodies", "headers", "securitySchemes", "links", "callbacks", "pathItems"}
+
+// unknownComponent is unknownField for the "components" object, whose members may be held by dedicated API fields.
+func unknownComponent(v interface{}, pointer string, found func(error) error) error {
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	fields := (&API{}).componentFields()
+	for _, kind := range sortedKeys(obj) {
+		if strings.HasPrefix(kind, "x-") {
+			continue
+		}
+
+		if !contains(componentKinds, kind) {
+			if err := found(fmt.Errorf("unknown field %q in %s", kind, pointer)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// Kinds which are not modeled, such as "callbacks", are kept as-is in API.OtherComponents
+		t := reflect.TypeOf(map[string]Type{})
+		if field, ok := fields[kind]; ok {
+			t = reflect.TypeOf(field).Elem()
+		} else if kind != "schemas" {
+			continue
+		}
+		if err := unknownField(t, obj[kind], pointer+"/"+escapeToken(kind), found); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonFields returns the types of the fields of the struct type t, keyed by their JSON name.
+// Embedded structs without a name in their JSON tag have their fields promoted, as per encoding/json.
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if name == "" && f.Anonymous {
+			if ft := indirect(f.Type); ft.Kind() == reflect.Struct {
+				for k, v := range jsonFields(ft) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Type
+	}
+
+	return fields
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseStrict(t *testing.T) {
+	tests := []struct {
+		name string
+		spec string
+		err  string // Substring of the expected error, or empty if none
+	}{
+		{
+			name: "header component",
+			spec: `{"openapi": "3.0.3", "info": {"title": "t", "version": "1"},
+				"paths": {"/pets": {"get": {"responses": {"200": {"description": "ok",
+					"headers": {"X-Rate": {"$ref": "#/components/headers/Rate"}}}}}}},
+				"components": {"headers": {"Rate": {"description": "Requests left", "schema": {"type": "integer"}}}}}`,
+		},
+		{
+			name: "link and callback components",
+			spec: `{"openapi": "3.0.3", "info": {"title": "t", "version": "1"},
+				"components": {
+					"links": {"Next": {"operationId": "list", "parameters": {"page": "$response.body#/next"}}},
+					"callbacks": {"Done": {"{$request.body#/url}": {"post": {"responses": {"200": {"description": "ok"}}}}}},
+					"x-internal": true}}`,
+		},
+		{
+			name: "keywords modeled",
+			spec: `{"openapi": "3.0.3", "info": {"title": "t", "version": "1"},
+				"paths": {"/pets": {"post": {
+					"parameters": [{"name": "q", "in": "query", "allowEmptyValue": true}],
+					"requestBody": {"content": {"multipart/form-data": {"schema": {"type": "object"},
+						"encoding": {"photo": {"contentType": "image/png", "style": "form", "explode": true}}}}},
+					"callbacks": {"done": {"{$request.body#/url}": {"post": {"responses": {"200": {"description": "ok"}}}}}},
+					"responses": {"200": {"description": "ok"}}}}},
+				"components": {"schemas": {"Pet": {"type": "object", "minProperties": 1, "maxProperties": 2, "xml": {"name": "pet"},
+					"properties": {"tags": {"type": "array", "uniqueItems": true, "items": {"type": "string", "xml": {"name": "tag"}}}}}}}}`,
+		},
+		{
+			name: "keywords not modeled",
+			spec: `{"openapi": "3.1.0", "info": {"title": "t", "version": "1", "summary": "s"},
+				"jsonSchemaDialect": "https://spec.openapis.org/oas/3.1/dialect/base",
+				"webhooks": {"new": {"post": {"responses": {"200": {"description": "ok"}}}}},
+				"paths": {"/pets": {"get": {
+					"parameters": [{"name": "q", "in": "query", "allowReserved": true},
+						{"name": "f", "in": "query", "content": {"application/json": {"schema": {"type": "object"}}}}],
+					"responses": {"200": {"description": "ok",
+						"headers": {"X-Rate": {"style": "simple", "explode": false, "examples": {}, "schema": {"type": "integer"}}},
+						"links": {"next": {"operationId": "list", "requestBody": "$response.body", "server": {"url": "/"}}}}}}}},
+				"components": {"schemas": {"Pet": {"type": "object", "externalDocs": {"url": "/"}, "$defs": {}, "patternProperties": {},
+					"properties": {"tags": {"type": "array", "items": {"type": "string", "readOnly": true, "example": "a", "deprecated": true}}}}}}}`,
+		},
+		{
+			name: "typo beside keywords not modeled",
+			spec: `{"openapi": "3.0.3", "info": {"title": "t", "version": "1"},
+				"paths": {"/pets": {"get": {"parameters": [{"name": "q", "in": "query", "allowReserved": true, "allowReservd": true}]}}}}`,
+			err: `unknown field "allowReservd" in /paths/~1pets/get/parameters/0`,
+		},
+		{
+			name: "typo in header component",
+			spec: `{"openapi": "3.0.3", "info": {"title": "t", "version": "1"},
+				"components": {"headers": {"Rate": {"schema": {"type": "integer"}, "requird": true}}}}`,
+			err: `unknown field "requird" in /components/headers/Rate`,
+		},
+		{
+			name: "typo in schema component",
+			spec: `{"openapi": "3.0.3", "info": {"title": "t", "version": "1"},
+				"components": {"schemas": {"Pet": {"type": "object", "propertis": {}}}}}`,
+			err: `unknown field "propertis" in /components/schemas/Pet`,
+		},
+		{
+			name: "unknown kind of component",
+			spec: `{"openapi": "3.0.3", "info": {"title": "t", "version": "1"}, "components": {"schemaz": {}}}`,
+			err:  `unknown field "schemaz" in /components`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseStrict(strings.NewReader(tt.spec))
+			switch {
+			case tt.err == "" && err != nil:
+				t.Fatalf("ParseStrict: unexpected error: %v", err)
+			case tt.err != "" && err == nil:
+				t.Fatalf("ParseStrict: expected error %q, got none", tt.err)
+			case tt.err != "" && !strings.Contains(err.Error(), tt.err):
+				t.Fatalf("ParseStrict: expected error %q, got %q", tt.err, err)
+			}
+		})
+	}
+}
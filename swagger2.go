@@ -0,0 +1,500 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ParseSwagger2 takes a io.Reader which provides a Swagger 2.0 JSON specification and translates it to an OpenAPI v3 API.
+//
+// The translation is lossy, in particular:
+//   - "definitions" become schemas in Components and "#/definitions/" references to them are rewritten to match
+//   - "host", "basePath", and "schemes" become one Server per scheme, assuming "https" if no scheme is given
+//   - "body" and "formData" parameters become a RequestBody with content for each type the operation consumes
+//   - References to shared "parameters" and "responses" are inlined
+//   - "collectionFormat" becomes the equivalent style and explode, except "tsv", which has no equivalent
+//   - Headers keep only their description, type, format, and items
+//   - Extensions of a "body" parameter are kept on the RequestBody, and of a "formData" parameter on its property
+//   - Extensions of security definitions are dropped
+func ParseSwagger2(r io.Reader) (API, error) {
+	b, err := io.ReadAll(bufio.NewReader(r))
+	if err != nil {
+		return API{}, err
+	}
+
+	var doc swagger2
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return API{}, err
+	}
+	if doc.Swagger != "2.0" {
+		return API{}, fmt.Errorf("unsupported Swagger version %q, expected 2.0", doc.Swagger)
+	}
+
+	api := API{
+		Version:      "3.0.3",
+		Info:         doc.Info,
+		Tags:         doc.Tags,
+		ExternalDocs: doc.ExternalDocs,
+		Security:     doc.Security,
+	}
+	api.Extensions, err = extensions(b)
+	if err != nil {
+		return api, err
+	}
+
+	schemes := doc.Schemes
+	if len(schemes) < 1 {
+		schemes = []string{"https"}
+	}
+	if doc.Host != "" {
+		for _, scheme := range schemes {
+			api.Servers = append(api.Servers, Server{URL: scheme + "://" + doc.Host + doc.BasePath})
+		}
+	} else if doc.BasePath != "" {
+		api.Servers = []Server{{URL: doc.BasePath}}
+	}
+
+	if doc.Definitions != nil {
+		api.Components = map[string]map[string]Type{"schemas": doc.Definitions}
+	}
+
+	for name, s := range doc.SecurityDefinitions {
+		if api.SecuritySchemes == nil {
+			api.SecuritySchemes = make(map[string]SecurityScheme)
+		}
+		api.SecuritySchemes[name] = s.securityScheme()
+	}
+
+	for path, item := range doc.Paths {
+		for k, raw := range item {
+			if !strings.HasPrefix(k, "x-") {
+				continue
+			}
+			if api.Paths == nil {
+				api.Paths = make(map[string]PathItem)
+			}
+			pi := api.Paths[path]
+			if pi.Extensions == nil {
+				pi.Extensions = make(map[string]json.RawMessage)
+			}
+			pi.Extensions[k] = raw
+			api.Paths[path] = pi
+		}
+
+		var shared []swagger2Parameter
+		if raw, ok := item["parameters"]; ok {
+			if err := json.Unmarshal(raw, &shared); err != nil {
+				return api, fmt.Errorf("%s: %w", path, err)
+			}
+		}
+
+		for verb, raw := range item {
+			if verb == "parameters" || verb == "$ref" || strings.HasPrefix(verb, "x-") {
+				continue
+			}
+
+			m, err := doc.method(raw, shared)
+			if err != nil {
+				return api, fmt.Errorf("%s %s: %w", verb, path, err)
+			}
+
+			if api.Paths == nil {
+				api.Paths = make(map[string]PathItem)
+			}
+			pi := api.Paths[path]
+			if pi.Methods == nil {
+				pi.Methods = make(map[string]Method)
+			}
+			pi.Methods[verb] = m
+			api.Paths[path] = pi
+		}
+	}
+
+	// Only references are rewritten, text which happens to match, such as in a description or example, is left as-is
+	api = rewrite(reflect.ValueOf(api), func(v reflect.Value) {
+		if !v.CanAddr() {
+			return
+		}
+		switch n := v.Addr().Interface().(type) {
+		case *Type:
+			n.Ref = swagger2Ref(n.Ref)
+		case *Property:
+			n.Ref = swagger2Ref(n.Ref)
+		case *Schema:
+			n.Ref = swagger2Ref(n.Ref)
+		}
+	}).Interface().(API)
+
+	return api, nil
+}
+
+// swagger2Ref returns ref, a reference to a Swagger 2.0 definition such as "#/definitions/Pet", as a reference to the corresponding schema component.
+// Other references are returned as-is.
+func swagger2Ref(ref string) string {
+	if strings.HasPrefix(ref, "#/definitions/") {
+		return "#/components/schemas/" + strings.TrimPrefix(ref, "#/definitions/")
+	}
+	return ref
+}
+
+// swagger2 is the portion of a Swagger 2.0 document which ParseSwagger2 translates.
+type swagger2 struct {
+	Swagger             string                                `json:"swagger"`
+	Info                Info                                  `json:"info"`
+	Host                string                                `json:"host"`
+	BasePath            string                                `json:"basePath"`
+	Schemes             []string                              `json:"schemes"`
+	Consumes            []string                              `json:"consumes"`
+	Produces            []string                              `json:"produces"`
+	Paths               map[string]map[string]json.RawMessage `json:"paths"`
+	Definitions         map[string]Type                       `json:"definitions"`
+	Parameters          map[string]swagger2Parameter          `json:"parameters"`
+	Responses           map[string]swagger2Response           `json:"responses"`
+	SecurityDefinitions map[string]swagger2SecurityScheme     `json:"securityDefinitions"`
+	Security            []map[string][]string                 `json:"security"`
+	Tags                []Tag                                 `json:"tags"`
+	ExternalDocs        *ExternalDocs                         `json:"externalDocs"`
+}
+
+// swagger2Operation is the portion of a Swagger 2.0 operation which differs from a Method.
+type swagger2Operation struct {
+	Consumes   []string                    `json:"consumes"`
+	Produces   []string                    `json:"produces"`
+	Parameters []swagger2Parameter         `json:"parameters"`
+	Responses  map[string]swagger2Response `json:"responses"`
+}
+
+// swagger2Parameter is a Swagger 2.0 parameter, whose type is given in place unless it is a body parameter.
+type swagger2Parameter struct {
+	Ref              string          `json:"$ref"`
+	Name             string          `json:"name"`
+	In               string          `json:"in"`
+	Description      string          `json:"description"`
+	Required         bool            `json:"required"`
+	Schema           *Schema         `json:"schema"`
+	Type             string          `json:"type"`
+	Format           string          `json:"format"`
+	Items            *Schema         `json:"items"`
+	Enums            Enum            `json:"enum"`
+	Default          json.RawMessage `json:"default"`
+	CollectionFormat string          `json:"collectionFormat"`
+	Constraints
+
+	Extensions map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (p *swagger2Parameter) UnmarshalJSON(data []byte) error {
+	type parameter swagger2Parameter
+	if err := json.Unmarshal(data, (*parameter)(p)); err != nil {
+		return err
+	}
+
+	var err error
+	p.Extensions, err = extensions(data)
+	return err
+}
+
+// swagger2Response is a Swagger 2.0 response, which has a single schema for all types produced.
+type swagger2Response struct {
+	Ref         string                     `json:"$ref"`
+	Description string                     `json:"description"`
+	Schema      *Schema                    `json:"schema"`
+	Headers     map[string]swagger2Header  `json:"headers"`
+	Examples    map[string]json.RawMessage `json:"examples"`
+
+	Extensions map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (r *swagger2Response) UnmarshalJSON(data []byte) error {
+	type response swagger2Response
+	if err := json.Unmarshal(data, (*response)(r)); err != nil {
+		return err
+	}
+
+	var err error
+	r.Extensions, err = extensions(data)
+	return err
+}
+
+// swagger2Header is a Swagger 2.0 header, whose type is given in place.
+type swagger2Header struct {
+	Description string  `json:"description"`
+	Type        string  `json:"type"`
+	Format      string  `json:"format"`
+	Items       *Schema `json:"items"`
+
+	Extensions map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting extensions.
+func (h *swagger2Header) UnmarshalJSON(data []byte) error {
+	type header swagger2Header
+	if err := json.Unmarshal(data, (*header)(h)); err != nil {
+		return err
+	}
+
+	var err error
+	h.Extensions, err = extensions(data)
+	return err
+}
+
+// swagger2SecurityScheme is a Swagger 2.0 security scheme.
+type swagger2SecurityScheme struct {
+	Type             string            `json:"type"`
+	Description      string            `json:"description"`
+	Name             string            `json:"name"`
+	In               string            `json:"in"`
+	Flow             string            `json:"flow"`
+	AuthorizationURL string            `json:"authorizationUrl"`
+	TokenURL         string            `json:"tokenUrl"`
+	Scopes           map[string]string `json:"scopes"`
+}
+
+// method translates the Swagger 2.0 operation raw, with the parameters shared by its path.
+func (doc swagger2) method(raw json.RawMessage, shared []swagger2Parameter) (Method, error) {
+	// Fields in common with OpenAPI v3 decode as-is, the remainder are replaced below
+	var m Method
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return m, err
+	}
+	m.Parameters, m.RequestBody, m.Responses = nil, RequestBody{}, nil
+
+	var op swagger2Operation
+	if err := json.Unmarshal(raw, &op); err != nil {
+		return m, err
+	}
+
+	consumes := firstNonEmpty(op.Consumes, doc.Consumes, []string{"application/json"})
+	produces := firstNonEmpty(op.Produces, doc.Produces, []string{"application/json"})
+
+	params, err := doc.parameters(shared, op.Parameters)
+	if err != nil {
+		return m, err
+	}
+
+	form := Schema{Type: Types{"object"}}
+	multipart := contains(consumes, "multipart/form-data")
+	for _, p := range params {
+		switch p.In {
+		case "body":
+			m.RequestBody = RequestBody{Description: p.Description, Required: p.Required, Content: make(Content), Extensions: p.Extensions}
+			for _, mediaType := range consumes {
+				if p.Schema != nil {
+					m.RequestBody.Content[mediaType] = MediaType{Schema: *p.Schema}
+				} else {
+					m.RequestBody.Content[mediaType] = MediaType{}
+				}
+			}
+
+		case "formData":
+			if form.Properties == nil {
+				form.Properties = make(map[string]Property)
+			}
+			form.Properties[p.Name] = p.property()
+			if p.Required {
+				form.Required = append(form.Required, p.Name)
+			}
+			multipart = multipart || p.Type == "file"
+
+		default:
+			m.Parameters = append(m.Parameters, p.parameter())
+		}
+	}
+
+	if form.Properties != nil {
+		mediaType := "application/x-www-form-urlencoded"
+		if multipart {
+			mediaType = "multipart/form-data"
+		}
+		m.RequestBody = RequestBody{Required: len(form.Required) > 0, Content: Content{mediaType: {Schema: form}}}
+	}
+
+	for code, resp := range op.Responses {
+		if resp.Ref != "" {
+			name := strings.TrimPrefix(resp.Ref, "#/responses/")
+			shared, ok := doc.Responses[name]
+			if !ok {
+				return m, fmt.Errorf("response %s: %s: no such response", code, resp.Ref)
+			}
+			resp = shared
+		}
+
+		if m.Responses == nil {
+			m.Responses = make(map[string]Response)
+		}
+		m.Responses[code] = resp.response(produces)
+	}
+
+	return m, nil
+}
+
+// parameters returns the parameters of an operation, with references inlined.
+// Operation parameters override those shared by the path which have the same name and location.
+func (doc swagger2) parameters(shared, own []swagger2Parameter) ([]swagger2Parameter, error) {
+	var params []swagger2Parameter
+	index := make(map[string]int)
+
+	for _, list := range [][]swagger2Parameter{shared, own} {
+		for _, p := range list {
+			if p.Ref != "" {
+				name := strings.TrimPrefix(p.Ref, "#/parameters/")
+				ref, ok := doc.Parameters[name]
+				if !ok {
+					return nil, fmt.Errorf("%s: no such parameter", p.Ref)
+				}
+				p = ref
+			}
+
+			key := p.In + " " + p.Name
+			if i, ok := index[key]; ok {
+				params[i] = p
+				continue
+			}
+			index[key] = len(params)
+			params = append(params, p)
+		}
+	}
+
+	return params, nil
+}
+
+// parameter translates a non-body parameter.
+func (p swagger2Parameter) parameter() Parameter {
+	out := Parameter{
+		Name:        p.Name,
+		In:          p.In,
+		Description: p.Description,
+		Required:    p.Required,
+		Schema: Schema{
+			Type:        swagger2Types(p.Type),
+			Format:      p.Format,
+			Items:       p.Items,
+			Enums:       p.Enums,
+			Default:     p.Default,
+			Constraints: p.Constraints,
+		},
+		Extensions: p.Extensions,
+	}
+
+	explode := func(b bool) *bool { return &b }
+	switch p.CollectionFormat {
+	case "csv", "":
+		if p.Type == "array" {
+			out.Explode = explode(false)
+			if p.In == "query" {
+				out.Style = "form"
+			}
+		}
+	case "multi":
+		out.Style, out.Explode = "form", explode(true)
+	case "ssv":
+		out.Style, out.Explode = "spaceDelimited", explode(false)
+	case "pipes":
+		out.Style, out.Explode = "pipeDelimited", explode(false)
+	}
+
+	return out
+}
+
+// property translates a formData parameter to a property of the request body.
+func (p swagger2Parameter) property() Property {
+	out := Property{
+		Type:        swagger2Types(p.Type),
+		Format:      p.Format,
+		Enums:       p.Enums,
+		Default:     p.Default,
+		Constraints: p.Constraints,
+		Extensions:  p.Extensions,
+	}
+
+	if p.Type == "file" {
+		out.Type, out.Format = Types{"string"}, "binary"
+	}
+	out.Items = p.Items
+
+	return out
+}
+
+// response translates a response, with content for each of the types produced.
+func (r swagger2Response) response(produces []string) Response {
+	out := Response{Description: r.Description, Extensions: r.Extensions}
+
+	for name, h := range r.Headers {
+		if out.Headers == nil {
+			out.Headers = make(map[string]Header)
+		}
+		out.Headers[name] = Header{Description: h.Description, Schema: Schema{Type: swagger2Types(h.Type), Format: h.Format, Items: h.Items}, Extensions: h.Extensions}
+	}
+
+	if r.Schema == nil && len(r.Examples) < 1 {
+		return out
+	}
+
+	out.Content = make(Content)
+	for _, mediaType := range produces {
+		mt := MediaType{Example: r.Examples[mediaType]}
+		if r.Schema != nil {
+			mt.Schema = *r.Schema
+		}
+		out.Content[mediaType] = mt
+	}
+
+	return out
+}
+
+// securityScheme translates a security scheme.
+func (s swagger2SecurityScheme) securityScheme() SecurityScheme {
+	out := SecurityScheme{Type: s.Type, Description: s.Description, Name: s.Name, In: s.In}
+
+	switch s.Type {
+	case "basic":
+		out.Type, out.Scheme = "http", "basic"
+
+	case "oauth2":
+		flow := &OAuthFlow{AuthorizationURL: s.AuthorizationURL, TokenURL: s.TokenURL, Scopes: s.Scopes}
+		if flow.Scopes == nil {
+			flow.Scopes = make(map[string]string)
+		}
+
+		out.Flows = &OAuthFlows{}
+		switch s.Flow {
+		case "implicit":
+			out.Flows.Implicit = flow
+		case "password":
+			out.Flows.Password = flow
+		case "application":
+			out.Flows.ClientCredentials = flow
+		case "accessCode":
+			out.Flows.AuthorizationCode = flow
+		}
+	}
+
+	return out
+}
+
+// swagger2Types converts the single type of a Swagger 2.0 object, if any, to Types.
+func swagger2Types(typ string) Types {
+	if typ == "" {
+		return nil
+	}
+	return Types{typ}
+}
+
+// firstNonEmpty returns the first of lists which is not empty.
+func firstNonEmpty(lists ...[]string) []string {
+	for _, l := range lists {
+		if len(l) > 0 {
+			return l
+		}
+	}
+	return nil
+}
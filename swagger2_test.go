@@ -0,0 +1,111 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testSwagger2 = `{
+	"swagger": "2.0",
+	"info": {"title": "Pets", "version": "1.0.0"},
+	"host": "pets.example.com",
+	"basePath": "/v1",
+	"schemes": ["https", "http"],
+	"consumes": ["application/json"],
+	"paths": {
+		"/pets/{id}": {
+			"x-ms-path": "pet",
+			"parameters": [{"$ref": "#/parameters/Id"}],
+			"put": {
+				"operationId": "putPet",
+				"description": "Replaces a pet, as described by \"#/definitions/Pet\"",
+				"parameters": [{"name": "pet", "in": "body", "required": true, "schema": {"$ref": "#/definitions/Pet"}, "x-ms-requestBody-name": "pet"}],
+				"responses": {
+					"200": {
+						"description": "The pet",
+						"schema": {"$ref": "#/definitions/Pet"},
+						"headers": {"X-Rate-Limit": {"type": "integer", "format": "int32", "description": "Requests left", "x-ms-header": true}},
+						"examples": {"application/json": {"$ref": "#/definitions/Pet"}}
+					}
+				}
+			}
+		},
+		"/pets/{id}/photo": {
+			"post": {
+				"operationId": "uploadPhoto",
+				"parameters": [
+					{"$ref": "#/parameters/Id"},
+					{"name": "photo", "in": "formData", "type": "file", "required": true, "x-ms-client-name": "image"},
+					{"name": "caption", "in": "formData", "type": "string"}
+				],
+				"responses": {"204": {"description": "Uploaded"}}
+			}
+		}
+	},
+	"parameters": {"Id": {"name": "id", "in": "path", "required": true, "type": "integer", "x-ms-parameter-location": "method"}},
+	"definitions": {
+		"Pet": {"type": "object", "description": "See #/definitions/Pet", "properties": {"owner": {"$ref": "#/definitions/Owner"}}},
+		"Owner": {"type": "object", "properties": {"name": {"type": "string"}}}
+	}
+}`
+
+func TestParseSwagger2(t *testing.T) {
+	api, err := ParseSwagger2(strings.NewReader(testSwagger2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errs := api.Validate(); len(errs) > 0 {
+		t.Errorf("converted specification is invalid: %v", errs)
+	}
+
+	put := api.Paths["/pets/{id}"].Methods["put"]
+	upload := api.Paths["/pets/{id}/photo"].Methods["post"]
+	form := upload.RequestBody.Content["multipart/form-data"].Schema
+
+	tests := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"servers", api.Servers, []Server{{URL: "https://pets.example.com/v1"}, {URL: "http://pets.example.com/v1"}}},
+		{"path extension", string(api.Paths["/pets/{id}"].Extensions["x-ms-path"]), `"pet"`},
+
+		// References to definitions are rewritten, but text which looks like one is not
+		{"body schema", put.RequestBody.Content["application/json"].Schema.Ref, "#/components/schemas/Pet"},
+		{"response schema", put.Responses["200"].Content["application/json"].Schema.Ref, "#/components/schemas/Pet"},
+		{"property", api.Components["schemas"]["Pet"].Properties["owner"].Ref, "#/components/schemas/Owner"},
+		{"description", put.Description, `Replaces a pet, as described by "#/definitions/Pet"`},
+		{"schema description", api.Components["schemas"]["Pet"].Description, "See #/definitions/Pet"},
+		{"example", string(put.Responses["200"].Content["application/json"].Example), `{"$ref": "#/definitions/Pet"}`},
+
+		// A body parameter becomes the request body, keeping its extensions
+		{"body required", put.RequestBody.Required, true},
+		{"body extension", string(put.RequestBody.Extensions["x-ms-requestBody-name"]), `"pet"`},
+
+		// Shared parameters are inlined into each operation, whether referenced by the path or by the operation
+		{"path parameters", api.Paths["/pets/{id}"].Parameters, []Parameter(nil)},
+		{"parameter from path", len(put.Parameters), 1},
+		{"parameter from operation", len(upload.Parameters), 1},
+		{"inlined parameter", upload.Parameters[0].Name, "id"},
+		{"inlined parameter extension", string(upload.Parameters[0].Extensions["x-ms-parameter-location"]), `"method"`},
+
+		// Form parameters become properties of a multipart request body, as one is a file
+		{"form required", form.Required, []string{"photo"}},
+		{"file", form.Properties["photo"].Format, "binary"},
+		{"form extension", string(form.Properties["photo"].Extensions["x-ms-client-name"]), `"image"`},
+		{"form property", form.Properties["caption"].Type, Types{"string"}},
+
+		{"header", put.Responses["200"].Headers["X-Rate-Limit"].Schema, Schema{Type: Types{"integer"}, Format: "int32"}},
+		{"header description", put.Responses["200"].Headers["X-Rate-Limit"].Description, "Requests left"},
+		{"header extension", string(put.Responses["200"].Headers["X-Rate-Limit"].Extensions["x-ms-header"]), "true"},
+	}
+
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.got, test.want) {
+			t.Errorf("%s: expected %#v, got %#v", test.name, test.want, test.got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// isZero reports whether v is the zero value of its type.
+func isZero(v interface{}) bool {
+	return reflect.ValueOf(v).IsZero()
+}
+
+// contains reports whether s is in list.
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+// indirect returns the type t points to, if t is a pointer, or t.
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// sortedKeys returns the keys of m, which must be a map with string keys, in lexical order.
+func sortedKeys(m interface{}) []string {
+	v := reflect.ValueOf(m)
+
+	keys := make([]string, 0, v.Len())
+	for _, k := range v.MapKeys() {
+		keys = append(keys, k.String())
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// typeName describes the type of a schema, naming the component for references.
+func typeName(s Schema) string {
+	switch {
+	case s.Ref != "":
+		return s.Ref[strings.LastIndex(s.Ref, "/")+1:]
+	case s.Type.Primary() == "array" && s.Items != nil:
+		return "[]" + typeName(*s.Items)
+	}
+	return s.Type.Primary()
+}
@@ -0,0 +1,470 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"mime"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// versionPattern matches an OpenAPI v3 semantic version, such as "3.0.3", or a version without a patch, such as "3.0", as used in the wild.
+var versionPattern = regexp.MustCompile(`^3\.\d+(\.\d+)?(-[0-9A-Za-z.-]+)?$`)
+
+// Validate runs every check of the API and returns their errors, in the order: ValidateVersion, ValidateRefs, ValidateMethods,
+// ValidateStatusCodes, ValidateParameters, ValidateOperationIDs, ValidateEnums, ValidateRequestBodies, ValidateMediaTypes, and ValidateRequiredProperties.
+// Errors with the same message, such as those found by several checks, are reported once.
+func (a API) Validate() []error {
+	var all []error
+	if err := a.ValidateVersion(); err != nil {
+		all = append(all, err)
+	}
+	for _, check := range []func() []error{a.ValidateRefs, a.ValidateMethods, a.ValidateStatusCodes, a.ValidateParameters, a.ValidateOperationIDs, a.ValidateEnums, a.ValidateRequestBodies, a.ValidateMediaTypes, a.ValidateRequiredProperties} {
+		all = append(all, check()...)
+	}
+
+	var errs []error
+	seen := make(map[string]bool)
+	for _, err := range all {
+		if !seen[err.Error()] {
+			seen[err.Error()] = true
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ValidateVersion returns an error if the API's OpenAPI version is absent or not of the form 3.x.y or 3.x.
+func (a API) ValidateVersion() error {
+	switch {
+	case a.Version == "":
+		return errors.New(`missing "openapi" version, the document may be Swagger 2.0`)
+	case !versionPattern.MatchString(a.Version):
+		return fmt.Errorf("unsupported OpenAPI version %q, expected 3.x.y or 3.x", a.Version)
+	}
+
+	return nil
+}
+
+// ValidateRefs returns an error for each schema reference which does not resolve against the API's components.
+// This includes the references in discriminator mappings.
+// Each error begins with the JSON pointer to where the reference appears.
+func (a API) ValidateRefs() []error {
+	var errs []error
+
+	walk(reflect.ValueOf(a), "", func(v reflect.Value, pointer string) error {
+		if d, ok := v.Interface().(Discriminator); ok {
+			for _, value := range sortedKeys(d.Mapping) {
+				if _, err := a.ResolveDiscriminator(d, value); err != nil {
+					errs = append(errs, fmt.Errorf("%s/mapping/%s: %w", pointer, escapeToken(value), err))
+				}
+			}
+			return nil
+		}
+
+		switch n := v.Interface().(type) {
+		case Parameter:
+			if n.Ref != "" {
+				if _, err := a.ResolveParameterRef(n.Ref); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", pointer, err))
+				}
+			}
+			return nil
+		case Response:
+			if n.Ref != "" {
+				if _, err := a.ResolveResponseRef(n.Ref); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", pointer, err))
+				}
+			}
+			return nil
+		case RequestBody:
+			if n.Ref != "" {
+				if _, err := a.ResolveRequestBodyRef(n.Ref); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", pointer, err))
+				}
+			}
+			return nil
+		case Example:
+			if n.Ref != "" {
+				if _, err := a.ResolveExampleRef(n.Ref); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", pointer, err))
+				}
+			}
+			return nil
+		case Header:
+			if n.Ref != "" {
+				if _, err := a.ResolveHeaderRef(n.Ref); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", pointer, err))
+				}
+			}
+			return nil
+		}
+
+		ref := schemaRef(v)
+		if ref == "" {
+			return nil
+		}
+		if _, err := a.ResolveRef(ref); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", pointer, err))
+		}
+		return nil
+	})
+
+	return errs
+}
+
+// verbs is the HTTP methods an OpenAPI path may define operations for, in the order the specification lists them.
+var verbs = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// isVerb reports whether verb is one of verbs.
+func isVerb(verb string) bool {
+	return contains(verbs, verb)
+}
+
+// ValidateMethods returns an error for each operation in Paths keyed by something other than a lowercase HTTP method.
+func (a API) ValidateMethods() []error {
+	var errs []error
+
+	for _, path := range sortedKeys(a.Paths) {
+		for _, verb := range sortedKeys(a.Paths[path].Methods) {
+			if !isVerb(verb) {
+				errs = append(errs, fmt.Errorf("%s: invalid HTTP method %q", path, verb))
+			}
+		}
+	}
+
+	return errs
+}
+
+// statusPattern matches an HTTP status code, such as "404", or a range of codes, such as "4XX".
+var statusPattern = regexp.MustCompile(`^[1-5](\d\d|XX)$`)
+
+// ValidateStatusCodes returns an error for each response keyed by something other than an HTTP status code, a range such as "2XX", or "default".
+func (a API) ValidateStatusCodes() []error {
+	var errs []error
+
+	for _, path := range sortedKeys(a.Paths) {
+		for _, verb := range sortedKeys(a.Paths[path].Methods) {
+			for _, code := range sortedKeys(a.Paths[path].Methods[verb].Responses) {
+				if code != "default" && !statusPattern.MatchString(code) {
+					errs = append(errs, fmt.Errorf("%s %s: invalid response status code %q", verb, path, code))
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// ValidateOperationIDs returns an error for each operationId used by more than one operation.
+// Operations without an operationId are ignored.
+func (a API) ValidateOperationIDs() []error {
+	uses := make(map[string][]string)
+
+	for _, path := range sortedKeys(a.Paths) {
+		for _, verb := range sortedKeys(a.Paths[path].Methods) {
+			if id := a.Paths[path].Methods[verb].OperationID; id != "" {
+				uses[id] = append(uses[id], verb+" "+path)
+			}
+		}
+	}
+
+	var errs []error
+	for _, id := range sortedKeys(uses) {
+		if len(uses[id]) > 1 {
+			errs = append(errs, fmt.Errorf("duplicate operationId %q: %s", id, strings.Join(uses[id], ", ")))
+		}
+	}
+
+	return errs
+}
+
+// parameterLocations is the values permitted for Parameter.In.
+var parameterLocations = []string{"query", "header", "path", "cookie"}
+
+// templatePattern matches a templated segment of a path, such as "{id}".
+var templatePattern = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// ValidateParameters returns an error for each parameter whose location is not one of "query", "header", "path", or "cookie",
+// and for each error of ValidatePathParams.
+func (a API) ValidateParameters() []error {
+	var errs []error
+
+	a.ForEachOperation(func(path, verb string, m Method) {
+		params := a.EffectiveParameters(path, verb)
+		for _, p := range params {
+			if p.Ref != "" {
+				continue
+			}
+			if !contains(parameterLocations, p.In) {
+				errs = append(errs, fmt.Errorf("%s %s: parameter %q: invalid location %q", verb, path, p.Name, p.In))
+			}
+		}
+
+		errs = append(errs, pathParamErrors(path, verb, params)...)
+	})
+
+	return errs
+}
+
+// ValidatePathParams returns an error for each templated segment of a path, such as "{id}", without a required path parameter of that name,
+// and for each path parameter not named by a templated segment of its path.
+// The parameters of each operation include those of its PathItem, as by EffectiveParameters.
+func (a API) ValidatePathParams() []error {
+	var errs []error
+	a.ForEachOperation(func(path, verb string, m Method) {
+		errs = append(errs, pathParamErrors(path, verb, a.EffectiveParameters(path, verb))...)
+	})
+	return errs
+}
+
+// pathParamErrors returns the errors of ValidatePathParams for the operation at path and verb, whose parameters are params.
+func pathParamErrors(path, verb string, params []Parameter) []error {
+	var errs []error
+
+	var names []string
+	for _, match := range templatePattern.FindAllStringSubmatch(path, -1) {
+		name := match[1]
+		names = append(names, name)
+
+		p, ok := findParameter(params, name, "path")
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%s %s: parameter %q: no path parameter for templated segment", verb, path, name))
+		case !p.Required:
+			errs = append(errs, fmt.Errorf("%s %s: parameter %q: path parameter is not required", verb, path, name))
+		}
+	}
+
+	for _, p := range params {
+		if p.In == "path" && !contains(names, p.Name) {
+			errs = append(errs, fmt.Errorf("%s %s: parameter %q: path parameter not in path template", verb, path, p.Name))
+		}
+	}
+
+	return errs
+}
+
+// ValidateRequestBodies returns an error for each post, put, or patch operation whose request body has content but is not required,
+// and for each get operation with a request body, which has no defined meaning.
+// References to request bodies are resolved, those which cannot be are reported by ValidateRefs.
+func (a API) ValidateRequestBodies() []error {
+	var errs []error
+
+	a.ForEachOperation(func(path, verb string, m Method) {
+		rb := m.RequestBody
+		if rb.Ref != "" {
+			var err error
+			if rb, err = a.ResolveRequestBodyRef(rb.Ref); err != nil {
+				return
+			}
+		}
+
+		switch {
+		case verb == "get" && !isZero(m.RequestBody):
+			errs = append(errs, fmt.Errorf("%s %s: request body on a get operation", verb, path))
+		case (verb == "post" || verb == "put" || verb == "patch") && len(rb.Content) > 0 && !rb.Required:
+			errs = append(errs, fmt.Errorf("%s %s: request body is not required", verb, path))
+		}
+	})
+
+	return errs
+}
+
+// ValidateEnums returns an error for each enumerated value of a string, integer, number, or boolean schema which is not of that type.
+// Null is permitted for any type.
+func (a API) ValidateEnums() []error {
+	var errs []error
+
+	walk(reflect.ValueOf(a), "", func(v reflect.Value, pointer string) error {
+		typ, enums := schemaEnums(v)
+		for i, e := range enums.values() {
+			if !enumMatches(typ, e) {
+				errs = append(errs, fmt.Errorf("%s/enum/%d: %s is not a valid %s", pointer, i, e, typ))
+			}
+		}
+		return nil
+	})
+
+	return errs
+}
+
+// schemaEnums returns the type and enumerated values of a Type, Property, or Schema.
+func schemaEnums(v reflect.Value) (typ string, enums Enum) {
+	switch n := v.Interface().(type) {
+	case Type:
+		return n.Is.Primary(), n.Enums
+	case Property:
+		return n.Type.Primary(), n.Enums
+	case Schema:
+		return n.Type.Primary(), n.Enums
+	}
+
+	return "", nil
+}
+
+// enumMatches reports whether the enumerated value e, as compact JSON text, is valid for the type typ.
+// Values of other types, such as objects, always match.
+func enumMatches(typ, e string) bool {
+	if e == "null" {
+		return true
+	}
+
+	switch typ {
+	case "string":
+		return strings.HasPrefix(e, `"`)
+	case "integer":
+		f, err := strconv.ParseFloat(e, 64)
+		return err == nil && f == math.Trunc(f)
+	case "number":
+		_, err := strconv.ParseFloat(e, 64)
+		return err == nil
+	case "boolean":
+		return e == "true" || e == "false"
+	}
+
+	return true
+}
+
+// mediaTypes is the top-level media types registered by RFC 6838 and its successors, and the wildcard.
+var mediaTypes = []string{"application", "audio", "example", "font", "haptics", "image", "message", "model", "multipart", "text", "video", "*"}
+
+// ValidateMediaTypes returns an error for each key of a Content, in operations and in the responses and request bodies of the components,
+// which is not a media type, such as "application/json; charset=utf-8" or "text/*".
+// Media types must have a registered top-level type, catching typos such as "aplication/json".
+func (a API) ValidateMediaTypes() []error {
+	var errs []error
+	check := func(where string, c Content) {
+		for _, key := range sortedKeys(c) {
+			if err := validMediaType(key); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", where, err))
+			}
+		}
+	}
+
+	a.ForEachOperation(func(path, verb string, m Method) {
+		check(fmt.Sprintf("%s %s: request body", verb, path), m.RequestBody.Content)
+		for _, code := range sortedKeys(m.Responses) {
+			check(fmt.Sprintf("%s %s: response %s", verb, path, code), m.Responses[code].Content)
+		}
+	})
+	for _, name := range sortedKeys(a.RequestBodies) {
+		check(componentRef("requestBodies", name), a.RequestBodies[name].Content)
+	}
+	for _, name := range sortedKeys(a.Responses) {
+		check(componentRef("responses", name), a.Responses[name].Content)
+	}
+
+	return errs
+}
+
+// validMediaType returns an error if s is not a media type, with optional parameters, such as "text/plain; charset=utf-8".
+func validMediaType(s string) error {
+	mediaType, _, err := mime.ParseMediaType(s)
+	if err != nil {
+		return fmt.Errorf("invalid media type %q: %w", s, err)
+	}
+
+	slash := strings.Index(mediaType, "/")
+	if slash < 0 {
+		return fmt.Errorf("invalid media type %q: no subtype", s)
+	}
+	typ, subtype := mediaType[:slash], mediaType[slash+1:]
+	switch {
+	case !contains(mediaTypes, typ):
+		return fmt.Errorf("invalid media type %q: unknown type %q", s, typ)
+	case subtype == "" || strings.Contains(subtype, "/"):
+		return fmt.Errorf("invalid media type %q: invalid subtype %q", s, subtype)
+	case typ == "*" && subtype != "*":
+		return fmt.Errorf("invalid media type %q: wildcard type with subtype %q", s, subtype)
+	}
+
+	return nil
+}
+
+// ValidateRequiredProperties returns an error for each name required by an object schema in Components which is not among its properties,
+// such as one renamed in Properties but not in Required, which no object could satisfy.
+// Properties of its allOf, anyOf, and oneOf schemas count, following references, and inline objects within a schema are checked too.
+// Schemas with neither properties nor allOf are not checked, as they constrain objects of any shape.
+func (a API) ValidateRequiredProperties() []error {
+	var errs []error
+	schemas := a.Components["schemas"]
+	for _, name := range sortedKeys(schemas) {
+		t := schemas[name]
+		t.Ref = ""
+		errs = append(errs, a.requiredErrors(typeProperty(t), componentRef("schemas", name))...)
+	}
+
+	return errs
+}
+
+// requiredErrors returns an error for each required name of p, and of the inline objects within it, which is not among its properties.
+// Where is the location of p, such as "#/components/schemas/Pet".
+func (a API) requiredErrors(p Property, where string) []error {
+	// Referenced schemas are checked as components
+	if p.Ref != "" {
+		return nil
+	}
+
+	var errs []error
+	if p.Properties != nil || len(p.AllOf) > 0 {
+		names := make(map[string]bool)
+		a.propertyNames(p, names, nil)
+		for _, name := range p.Required {
+			if !names[name] {
+				errs = append(errs, fmt.Errorf("%s: required property %q is not among its properties", where, name))
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(p.Properties) {
+		errs = append(errs, a.requiredErrors(p.Properties[name], where+"/properties/"+escapeToken(name))...)
+	}
+	if p.Items != nil {
+		errs = append(errs, a.requiredErrors(p.Items.property(), where+"/items")...)
+	}
+	if ap := p.AdditionalProperties; ap != nil && ap.Property != nil {
+		errs = append(errs, a.requiredErrors(*ap.Property, where+"/additionalProperties")...)
+	}
+	for _, c := range []struct {
+		keyword string
+		types   []Type
+	}{{"allOf", p.AllOf}, {"anyOf", p.AnyOf}, {"oneOf", p.OneOf}} {
+		for i, t := range c.types {
+			errs = append(errs, a.requiredErrors(typeProperty(t), fmt.Sprintf("%s/%s/%d", where, c.keyword, i))...)
+		}
+	}
+
+	return errs
+}
+
+// propertyNames adds the names of the properties of p, and of its allOf, anyOf, and oneOf schemas, to names, following references.
+// Chain holds the references being followed, references which cannot be resolved are reported by ValidateRefs.
+func (a API) propertyNames(p Property, names map[string]bool, chain []string) {
+	if p.Ref != "" {
+		if contains(chain, p.Ref) {
+			return
+		}
+		t, err := a.ResolveRef(p.Ref)
+		if err != nil {
+			return
+		}
+		a.propertyNames(typeProperty(t), names, append(chain, p.Ref))
+		return
+	}
+
+	for name := range p.Properties {
+		names[name] = true
+	}
+	for _, types := range [][]Type{p.AllOf, p.AnyOf, p.OneOf} {
+		for _, t := range types {
+			a.propertyNames(typeProperty(t), names, chain)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateVersion(t *testing.T) {
+	tests := []struct {
+		version string
+		err     string // Substring of the expected error, or empty if none
+	}{
+		{version: "3.0.3"},
+		{version: "3.1.0"},
+		{version: "3.0"},
+		{version: "3.1.0-rc1"},
+		{version: "", err: "missing"},
+		{version: "2.0", err: "unsupported"},
+		{version: "3", err: "unsupported"},
+		{version: "3.0.", err: "unsupported"},
+		{version: "3.0.3.1", err: "unsupported"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			err := API{Version: test.version}.ValidateVersion()
+			switch {
+			case test.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.err != "" && err == nil:
+				t.Fatalf("expected error containing %q", test.err)
+			case test.err != "" && !strings.Contains(err.Error(), test.err):
+				t.Fatalf("error %q does not contain %q", err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Walk calls visit, depth-first, for every Type, Property, and Schema in the API, including those within operations.
+// Each node is accompanied by the reference tokens of the JSON pointer at which it appears, such as ["components", "schemas", "Pet"].
+// Nodes are copies, so modifying them does not modify the API.
+// An error returned by visit stops the walk and is returned.
+func (a API) Walk(visit func(node interface{}, path []string) error) error {
+	return walk(reflect.ValueOf(a), "", func(v reflect.Value, pointer string) error {
+		switch v.Interface().(type) {
+		case Type, Property, Schema:
+			return visit(v.Interface(), pointerTokens(pointer))
+		}
+		return nil
+	})
+}
+
+// errFound stops a walk once the value sought is found.
+var errFound = errors.New("found")
+
+// Get returns the value at the JSON pointer within the API, such as the string at "/paths/~1users~1{id}/get/responses/200/description".
+// Reference tokens are escaped per RFC 6901, "~1" for "/" and "~0" for "~", and array elements are addressed by index.
+// Values are copies, so modifying them does not modify the API.
+// Extensions may be addressed, but not values within raw JSON, such as within an example or extension.
+func (a API) Get(pointer string) (interface{}, error) {
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%s: not a JSON pointer", pointer)
+	}
+	if invalidEscapePattern.MatchString(pointer) {
+		return nil, fmt.Errorf("%s: invalid escape", pointer)
+	}
+
+	var found interface{}
+	find := func(pointer string) bool {
+		err := walk(reflect.ValueOf(a), "", func(v reflect.Value, at string) error {
+			if at == pointer {
+				found = v.Interface()
+				return errFound
+			}
+			return nil
+		})
+		return err == errFound
+	}
+	if find(pointer) {
+		return found, nil
+	}
+
+	// Extensions are not walked, as they are not serialized as fields
+	if i := strings.LastIndexByte(pointer, '/'); i >= 0 && strings.HasPrefix(pointer[i+1:], "x-") && find(pointer[:i]) {
+		if v := reflect.ValueOf(found); v.Kind() == reflect.Struct && v.FieldByName("Extensions").IsValid() {
+			if ext, ok := v.FieldByName("Extensions").Interface().(map[string]json.RawMessage); ok {
+				if raw, ok := ext[unescapeToken(pointer[i+1:])]; ok {
+					return raw, nil
+				}
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("%s: no such value", pointer)
+}
+
+// invalidEscapePattern matches a "~" in a JSON pointer which is not part of an escape.
+var invalidEscapePattern = regexp.MustCompile(`~([^01]|$)`)
+
+// pointerTokens splits a JSON pointer into its unescaped reference tokens.
+func pointerTokens(pointer string) []string {
+	if pointer == "" {
+		return []string{}
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for i, t := range tokens {
+		tokens[i] = unescapeToken(t)
+	}
+	return tokens
+}
+
+// walk calls fn, depth-first, for v and every value reachable from v.
+// Each value is accompanied by the JSON pointer at which it would appear when serialized.
+// Map members are visited in key order.
+// An error returned by fn stops the walk and is returned.
+func walk(v reflect.Value, pointer string, fn func(v reflect.Value, pointer string) error) error {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if err := fn(v, pointer); err != nil {
+		return err
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		if err := walkFields(v, pointer, fn); err != nil {
+			return err
+		}
+
+		// Operations, which are members of the path item
+		if p, ok := v.Interface().(PathItem); ok {
+			for _, verb := range sortedVerbs(p.Methods) {
+				if err := walk(reflect.ValueOf(p.Methods[verb]), pointer+"/"+escapeToken(verb), fn); err != nil {
+					return err
+				}
+			}
+		}
+
+		// Components with a dedicated field
+		if a, ok := v.Interface().(API); ok {
+			fields := a.componentFields()
+			for _, kind := range sortedKeys(fields) {
+				if err := walk(reflect.ValueOf(fields[kind]), pointer+"/components/"+kind, fn); err != nil {
+					return err
+				}
+			}
+		}
+
+	case reflect.Map:
+		for _, name := range sortedKeys(v.Interface()) {
+			k := reflect.ValueOf(name).Convert(v.Type().Key())
+			if err := walk(v.MapIndex(k), pointer+"/"+escapeToken(name), fn); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		// Raw JSON is opaque
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := walk(v.Index(i), pointer+"/"+strconv.Itoa(i), fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkFields walks the serialized fields of the struct v.
+// Embedded structs without a name in their JSON tag are walked in place, as their fields are serialized in place per encoding/json.
+func walkFields(v reflect.Value, pointer string, fn func(v reflect.Value, pointer string) error) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		if name == "" && f.Anonymous && indirect(f.Type).Kind() == reflect.Struct {
+			if err := walk(fv, pointer, fn); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if name == "" {
+			name = f.Name
+		}
+		if err := walk(fv, pointer+"/"+escapeToken(name), fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rewrite returns a deep copy of v, sharing no maps, slices, or pointers with it.
+// Unlike walk, every field is copied, including those not serialized.
+// Fn is called with each value of the copy, after its own members are copied, and may modify it through Addr.
+func rewrite(v reflect.Value, fn func(v reflect.Value)) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+
+	switch v.Kind() {
+	case reflect.Ptr:
+		if !v.IsNil() {
+			out.Set(reflect.New(v.Type().Elem()))
+			out.Elem().Set(rewrite(v.Elem(), fn))
+		}
+
+	case reflect.Interface:
+		if !v.IsNil() {
+			out.Set(rewrite(v.Elem(), fn))
+		}
+
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).PkgPath == "" {
+				out.Field(i).Set(rewrite(v.Field(i), fn))
+			}
+		}
+
+	case reflect.Map:
+		if !v.IsNil() {
+			out.Set(reflect.MakeMapWithSize(v.Type(), v.Len()))
+			for it := v.MapRange(); it.Next(); {
+				out.SetMapIndex(it.Key(), rewrite(it.Value(), fn))
+			}
+		}
+
+	case reflect.Slice:
+		if !v.IsNil() {
+			out.Set(reflect.MakeSlice(v.Type(), v.Len(), v.Len()))
+			// Raw JSON is opaque
+			if v.Type().Elem().Kind() == reflect.Uint8 {
+				reflect.Copy(out, v)
+				break
+			}
+			for i := 0; i < v.Len(); i++ {
+				out.Index(i).Set(rewrite(v.Index(i), fn))
+			}
+		}
+
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			out.Index(i).Set(rewrite(v.Index(i), fn))
+		}
+
+	default:
+		out.Set(v)
+	}
+
+	fn(out)
+	return out
+}
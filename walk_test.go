@@ -0,0 +1,57 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	api, err := ParseString(testSpec)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		pointer string
+		want    interface{}
+		err     string // Substring of the expected error, or empty if none
+	}{
+		{pointer: "/paths/~1pets~1{id}/get/summary", want: "Get a pet"},
+		{pointer: "/paths/~1pets~1{id}/get/responses/200/description", want: "The pet"},
+		{pointer: "/paths/~1pets~1{id}/get/parameters/0/name", want: "verbose"},
+		{pointer: "/components/schemas/Pet/required/0", want: "id"},
+		{pointer: "/components/schemas/Pet/properties/id/format", want: "int64"},
+		{pointer: "/components/headers/RateLimit/description", want: "Requests left"},
+		{pointer: "/servers/0/url", want: "https://pets.example.com/v1"},
+		{pointer: "/info/x-audience", want: json.RawMessage(`"public"`)},
+		{pointer: "/components/schemas/Pet/properties/id/x-ms-client-name", want: json.RawMessage(`"petId"`)},
+		{pointer: "/info", want: api.Info},
+		{pointer: "", want: api},
+		{pointer: "info", err: "not a JSON pointer"},
+		{pointer: "/paths/~2pets", err: "invalid escape"},
+		{pointer: "/paths/~1owners", err: "no such value"},
+		{pointer: "/servers/1", err: "no such value"},
+		{pointer: "/info/x-none", err: "no such value"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.pointer, func(t *testing.T) {
+			got, err := api.Get(test.pointer)
+			switch {
+			case test.err == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case test.err != "" && err == nil:
+				t.Fatalf("expected error containing %q, got %v", test.err, got)
+			case test.err != "" && !strings.Contains(err.Error(), test.err):
+				t.Fatalf("error %q does not contain %q", err, test.err)
+			case test.err == "" && !reflect.DeepEqual(got, test.want):
+				t.Fatalf("expected %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,255 @@
+// Copyright (c) 2021, Microsoft Corporation, Sean Hinchee
+// Licensed under the MIT License.
+
+package openapi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ParseYAML takes a io.Reader which provides an OpenAPI v3 YAML specification and deserializes to an API.
+// The YAML is converted to JSON, preserving key order, and decoded as per Parse.
+func ParseYAML(r io.Reader) (API, error) {
+	br := bufio.NewReader(r)
+
+	var doc yaml.Node
+
+	dec := yaml.NewDecoder(br)
+	if err := dec.Decode(&doc); err != nil {
+		return API{}, err
+	}
+
+	var buf bytes.Buffer
+	if err := yamlToJSON(&buf, &doc); err != nil {
+		return API{}, err
+	}
+
+	return ParseBytes(buf.Bytes())
+}
+
+// WriteYAML serializes an API to w as OpenAPI v3 YAML.
+// Keys are emitted in the same order as Write, starting with openapi, info, servers, paths, and components.
+func (a API) WriteYAML(w io.Writer) error {
+	b, err := a.Marshal()
+	if err != nil {
+		return err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	doc, err := jsonToYAML(dec)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(w)
+
+	enc := yaml.NewEncoder(bw)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// jsonToYAML reads the next JSON value from dec as a YAML node, preserving key order.
+// The decoder must be configured with UseNumber.
+func jsonToYAML(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch v := tok.(type) {
+	case json.Delim:
+		n := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		if v == '{' {
+			n = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		}
+
+		for dec.More() {
+			if n.Kind == yaml.MappingNode {
+				k, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				n.Content = append(n.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: k.(string)})
+			}
+
+			c, err := jsonToYAML(dec)
+			if err != nil {
+				return nil, err
+			}
+			n.Content = append(n.Content, c)
+		}
+
+		// Closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return n, nil
+
+	case string:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: v}, nil
+
+	case json.Number:
+		tag := "!!int"
+		if strings.ContainsAny(v.String(), ".eE") {
+			tag = "!!float"
+		}
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: v.String()}, nil
+
+	case bool:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: strconv.FormatBool(v)}, nil
+
+	case nil:
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}, nil
+	}
+
+	return nil, fmt.Errorf("unexpected JSON token %v", tok)
+}
+
+// maxYAMLAliased is the number of nodes which aliases may add to a YAML document once expanded.
+// Each alias is written out in full, so a small document of aliases to aliases could otherwise expand without bound.
+const maxYAMLAliased = 1 << 20
+
+// yamlToJSON writes the YAML node n to buf as JSON.
+// Aliases are expanded, failing if they would add more than maxYAMLAliased nodes.
+func yamlToJSON(buf *bytes.Buffer, n *yaml.Node) error {
+	budget := yamlNodes(n) + maxYAMLAliased
+	return writeYAMLJSON(buf, n, &budget)
+}
+
+// yamlNodes returns the number of nodes in n, not following aliases.
+func yamlNodes(n *yaml.Node) int {
+	count := 1
+	for _, c := range n.Content {
+		count += yamlNodes(c)
+	}
+	return count
+}
+
+// writeYAMLJSON writes the YAML node n to buf as JSON, counting each node written against budget.
+func writeYAMLJSON(buf *bytes.Buffer, n *yaml.Node, budget *int) error {
+	if *budget--; *budget < 0 {
+		return fmt.Errorf("line %d: excessive aliasing", n.Line)
+	}
+
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) < 1 {
+			buf.WriteString("null")
+			return nil
+		}
+		return writeYAMLJSON(buf, n.Content[0], budget)
+
+	case yaml.AliasNode:
+		return writeYAMLJSON(buf, n.Alias, budget)
+
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, c := range n.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeYAMLJSON(buf, c, budget); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		first := true
+		err := yamlMembers(n, func(k string, v *yaml.Node) error {
+			if !first {
+				buf.WriteByte(',')
+			}
+			first = false
+
+			name, err := marshal(k)
+			if err != nil {
+				return err
+			}
+			buf.Write(name)
+			buf.WriteByte(':')
+			return writeYAMLJSON(buf, v, budget)
+		})
+		buf.WriteByte('}')
+		return err
+
+	case yaml.ScalarNode:
+		switch n.ShortTag() {
+		case "!!null", "!!bool", "!!int", "!!float":
+			var v interface{}
+			if err := n.Decode(&v); err != nil {
+				return err
+			}
+			b, err := marshal(v)
+			if err != nil {
+				return fmt.Errorf("line %d: %w", n.Line, err)
+			}
+			buf.Write(b)
+
+		default:
+			// Strings, timestamps, and binary are kept verbatim
+			b, err := marshal(n.Value)
+			if err != nil {
+				return err
+			}
+			buf.Write(b)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("line %d: unsupported YAML node kind %v", n.Line, n.Kind)
+}
+
+// yamlMembers calls fn for each key and value of the mapping node n, in order.
+// Merge keys ("<<") are expanded in place.
+func yamlMembers(n *yaml.Node, fn func(k string, v *yaml.Node) error) error {
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		for v.Kind == yaml.AliasNode {
+			v = v.Alias
+		}
+
+		if k.Kind != yaml.ScalarNode || k.ShortTag() != "!!merge" {
+			if err := fn(k.Value, v); err != nil {
+				return err
+			}
+			continue
+		}
+
+		merged := []*yaml.Node{v}
+		if v.Kind == yaml.SequenceNode {
+			merged = v.Content
+		}
+		for _, m := range merged {
+			for m.Kind == yaml.AliasNode {
+				m = m.Alias
+			}
+			if m.Kind != yaml.MappingNode {
+				return fmt.Errorf("line %d: merge key value is not a mapping", m.Line)
+			}
+			if err := yamlMembers(m, fn); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}